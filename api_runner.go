@@ -2,13 +2,14 @@ package pica
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/jerloo/funny"
 )
 
@@ -17,16 +18,39 @@ type APIRunner struct {
 	Filename string
 	APINames []string
 	Delay    int
+	// Selector filters the apis to run, together with APINames
+	Selector *Selector
+	// Parallel the max count of api items running at the same time
+	Parallel int
+	// SnapshotAll compares the response of every api with its snapshot
+	SnapshotAll bool
+	// UpdateSnapshots overwrites the snapshots changed instead of failing
+	UpdateSnapshots bool
+	// Reporter receives the events of the run, DefaultOutput by default
+	Reporter Reporter
 
 	content []byte
 	vm      *funny.Funny
 	parser  *funny.Parser
 	client  *http.Client
-	output  *Output
+	jar     *CookieJar
+	tokens  *tokenCache
+	// transports of the settings like tls, shared by the forks of the runner
+	transports *transportCache
 
 	APIItems  []*ApiItem
 	Block     *funny.Block
 	InitLines *funny.Block
+
+	// Variables are assigned after the init lines, so they override the file defaults
+	Variables map[string]funny.Value
+	Results   []*ApiResult
+
+	// result of the item running
+	result *ApiResult
+	// item and response statement evaluating, used by builtins like waitUntil
+	item      *ApiItem
+	statement funny.Statement
 }
 
 // NewAPIRunnerFromFile create a runner from a pica file
@@ -36,73 +60,136 @@ func NewAPIRunnerFromFile(filename string, apiNames []string, delay int) *APIRun
 		APINames: apiNames,
 		Delay:    delay,
 
-		client: http.DefaultClient,
-		vm:     funny.NewFunnyWithScope(DefaultInitScope),
-		output: DefaultOutput,
+		client:     http.DefaultClient,
+		jar:        NewCookieJar(),
+		tokens:     newTokenCache(),
+		transports: newTransportCache(),
+		vm:         newFunny(newInitScope()),
+		InitLines:  &funny.Block{},
+		Reporter:   DefaultOutput,
 	}
 }
 
 // NewAPIRunnerFromContent create a runner from a pica content
 func NewAPIRunnerFromContent(content []byte) *APIRunner {
 	return &APIRunner{
-		Filename: "",
-		APINames: []string{},
-		Delay:    0,
-		content:  content,
-		client:   http.DefaultClient,
-		vm:       funny.NewFunnyWithScope(DefaultInitScope),
-		output:   DefaultOutput,
+		Filename:   "",
+		APINames:   []string{},
+		Delay:      0,
+		content:    content,
+		client:     http.DefaultClient,
+		jar:        NewCookieJar(),
+		tokens:     newTokenCache(),
+		transports: newTransportCache(),
+		vm:         newFunny(newInitScope()),
+		InitLines:  &funny.Block{},
+		Reporter:   DefaultOutput,
+	}
+}
+
+// newFunny create a vm with its own copy of the builtin functions,
+// so the functions registered by one runner never leak into another
+func newFunny(scope funny.Scope) *funny.Funny {
+	vm := funny.NewFunnyWithScope(scope)
+	vm.Functions = make(map[string]funny.BuiltinFunction, len(funny.FUNCTIONS))
+	for name, fn := range funny.FUNCTIONS {
+		vm.Functions[name] = fn
+	}
+	return vm
+}
+
+// registerFunctions register the pica builtin functions into vm
+func (runner *APIRunner) registerFunctions(vm *funny.Funny) {
+	vm.Functions["address"] = Address
+	vm.Functions["email"] = Email
+	vm.Functions["phone"] = Phone
+	vm.Functions["words"] = Words
+	vm.Functions["name"] = FullName
+	vm.Functions["waitUntil"] = runner.WaitUntil
+	vm.Functions["jsonpath"] = JsonPath
+	vm.Functions["xpath"] = XPath
+	vm.Functions["file"] = runner.File
+	vm.Functions["setCookie"] = runner.SetCookie
+	vm.Functions["clearCookies"] = runner.ClearCookies
+	vm.Functions["assertSchema"] = runner.AssertSchema
+	vm.Functions["snapshot"] = runner.Snapshot
+	for name := range expectFunctions {
+		vm.Functions[name] = runner.expect(name)
 	}
 }
 
 // Run run the task
-func (runner *APIRunner) Run() error {
-	runner.vm.RegisterFunction("address", Address)
-	runner.vm.RegisterFunction("email", Email)
-	runner.vm.RegisterFunction("phone", Phone)
-	runner.vm.RegisterFunction("words", Words)
-	runner.vm.RegisterFunction("name", FullName)
-	err := runner.Parse()
+func (runner *APIRunner) Run() (err error) {
+	items, err := runner.prepare()
+	runner.Reporter.RunStart(runner.Filename, items)
+	defer func() {
+		runner.Reporter.RunEnd(runner.Results, err)
+	}()
 	if err != nil {
 		return err
 	}
+	if runner.Parallel > 1 {
+		return runner.runParallel(items)
+	}
+	for _, item := range items {
+		err = runner.RunSingle(item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepare parse the file, run its init lines and plan the items to run
+func (runner *APIRunner) prepare() ([]*ApiItem, error) {
+	runner.registerFunctions(runner.vm)
+	err := runner.Parse()
+	if err != nil {
+		return nil, err
+	}
 	// parse api file to ApiRequest
 	err = runner.ParseAPIItems()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	runner.RunInitLines()
+	for name, val := range runner.Variables {
+		runner.vm.Assign(name, val)
+	}
+	return runner.Plan()
+}
 
-	for i := 0; i < len(runner.APIItems); i++ {
-		item := runner.APIItems[i]
-		if len(runner.APINames) == 0 {
-			err = runner.RunSingle(item)
-			if err != nil {
-				return err
-			}
-		} else {
-			for index := 0; index < len(runner.APINames); index++ {
-				name := runner.APINames[index]
-				if item.Request.Name == name {
-					err = runner.RunSingle(item)
-					if err != nil {
-						return err
-					}
-				}
-			}
-		}
+// selected whether the item is chosen by APINames and Selector
+func (runner *APIRunner) selected(item *ApiItem) bool {
+	if len(runner.APINames) > 0 && !matchAnyName(runner.APINames, item.Request.Name) {
+		return false
 	}
-	return nil
+	return runner.Selector.Match(item.Request)
 }
 
 // Parse parse pica file
 func (runner *APIRunner) Parse() error {
+	if runner.content == nil {
+		data, err := ioutil.ReadFile(runner.Filename)
+		if err != nil {
+			return err
+		}
+		runner.content = data
+	}
 	runner.parser = funny.NewParser(runner.content, runner.Filename)
 	runner.Block = runner.parser.Parse()
 	return nil
 }
 
+// resolvePath paths of files used by the pica file are relative to it
+func (runner *APIRunner) resolvePath(path string) string {
+	if !filepath.IsAbs(path) && runner.Filename != "" {
+		return filepath.Join(filepath.Dir(runner.Filename), path)
+	}
+	return path
+}
+
 // RunInitLines run the code of initialization
 func (runner *APIRunner) RunInitLines() {
 	for _, line := range runner.InitLines.Statements {
@@ -111,94 +198,217 @@ func (runner *APIRunner) RunInitLines() {
 }
 
 // RunSingle run the single api item
-func (runner *APIRunner) RunSingle(item *ApiItem) error {
+func (runner *APIRunner) RunSingle(item *ApiItem) (err error) {
+	result := &ApiResult{
+		Name:   item.Request.Name,
+		Method: item.Request.Method,
+		Url:    item.Request.Url,
+	}
+	runner.Results = append(runner.Results, result)
+	runner.result = result
+	runner.Reporter.ItemStart(item)
+	start := time.Now()
+	defer func() {
+		// funny reports failed assertions and runtime errors by panicking
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s %s: %v", item.Request.Method, item.Request.Url, r)
+		}
+		result.Duration = time.Since(start)
+		result.Passed = err == nil
+		if err != nil {
+			result.Error = err.Error()
+		}
+		runner.Reporter.ItemEnd(item, result)
+	}()
+
 	// assign vars
 
 	runner.vm.Assign("url", item.Request.Url)
+	// `body` is the response of the last api until the request lines assign it
+	runner.vm.Assign("body", nil)
 	// Eval init scope statements
 	for _, line := range item.Request.lines.Statements {
 		runner.vm.EvalStatement(line)
 	}
+	item.Request.body = runner.vm.LookupDefault("body", nil)
+
+	err = runner.send(item)
+	if err != nil {
+		return err
+	}
 
-	headers := runner.vm.Lookup("headers").(map[string]funny.Value)
+	// Eval item response statement
+	runner.item = item
+	defer func() {
+		runner.item = nil
+		runner.statement = nil
+	}()
+	for _, line := range item.Response.lines.Statements {
+		runner.statement = line
+		runner.vm.EvalStatement(line)
+	}
+	if runner.SnapshotAll && findAssertion(result, "snapshot") == nil {
+		assertion, err := runner.snapshot(item, nil)
+		if err != nil {
+			return fmt.Errorf("%s %s: snapshot %s", item.Request.Method, item.Request.Url, err.Error())
+		}
+		result.Assertions = append(result.Assertions, assertion)
+		runner.Reporter.AssertionResult(item, assertion)
+	}
 
+	var failures []string
+	for _, assertion := range result.Assertions {
+		if !assertion.Passed {
+			failures = append(failures, assertion.Message())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s %s: %d expectations failed\n%s", item.Request.Method, item.Request.Url, len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// send the request of item and bind the response to the vm as
+// `status`, `header`, `body`, `redirects`, `timing` and the decoded body like `json`, `xml`, `yaml`, `form` or `text`
+func (runner *APIRunner) send(item *ApiItem) error {
 	// send ApiRequest by http client
 	res, err := runner.DoAPIRequest(item.Request)
 	if err != nil {
-		runner.output.ErrorRequest(err)
 		return fmt.Errorf("do http request error %s", err.Error())
 	}
 
 	buf := new(bytes.Buffer)
 	buf.ReadFrom(res.Body)
+	timing := ResponseTiming(res)
 
 	item.Response.Headers = res.Header
 	item.Response.Status = res.StatusCode
 	item.Response.Body = buf.Bytes()
+	if runner.result != nil {
+		runner.result.Status = res.StatusCode
+		runner.result.Timing = timing
+	}
 
 	// collect http response to ApiRequest
-	headers = HttpHeaders2VmMap(item.Response.Headers)
+	headers := HttpHeaders2VmMap(item.Response.Headers)
 	runner.vm.Assign("header", headers)
 	runner.vm.Assign("status", item.Response.Status)
 	runner.vm.Assign("body", item.Response.Body)
-
-	contentType := item.Response.Headers.Get("Content-Type")
-	if strings.HasPrefix(contentType, "application/json") {
-		jResults := make(map[string]funny.Value)
-		jun := make(map[string]interface{})
-		err := json.Unmarshal(item.Response.Body, &jun)
-		if err != nil {
-			color.Red(fmt.Sprintf("json binding %s %s", err.Error(), item.Response.Body))
-		}
-		for k, v := range jun {
-			jResults[k] = funny.Value(v)
-		}
-		runner.vm.Assign("json", jResults)
-
-		runner.output.Json(&jResults)
-	} else {
-		resData, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			color.Red(err.Error())
-		}
-		fmt.Print(string(resData))
+	runner.vm.Assign("cookies", runner.cookiesMap(item.Request, res))
+	runner.vm.Assign("redirects", redirectChain(res))
+	if timing != nil {
+		runner.vm.Assign("timing", timing.Map())
 	}
 
-	// Eval item response statement
-	for _, line := range item.Response.lines.Statements {
-		runner.vm.EvalStatement(line)
+	// the decoded body is bound by the name of its decoder like `json` or `xml`,
+	// nil when decoding fails, and the error is kept in the result for the reporters.
+	// The variables of the other decoders are nil, not the documents of the last api.
+	for _, registered := range Decoders {
+		runner.vm.Assign(registered.Name, nil)
+	}
+	decoder, doc, err := decodeResponse(item.Response)
+	if decoder != nil {
+		runner.vm.Assign(decoder.Name, doc)
+	}
+	if err != nil && runner.result != nil {
+		runner.result.DecodeError = fmt.Sprintf("decode %s: %s", decoder.Name, err.Error())
 	}
-
 	return nil
 }
 
-// DoAPIRequest run the api request
+// DoAPIRequest run the api request, retrying it by the policy of the request
 func (runner *APIRunner) DoAPIRequest(req *ApiRequest) (*http.Response, error) {
-
-	runner.output.EchoStartRequest(req, runner)
-
-	httpReq, err := CreateHttpRequest(req, runner)
+	policy, err := runner.Policy(req)
 	if err != nil {
 		return nil, err
 	}
-
-	runner.output.Headers(httpReq.Header)
-	runner.output.RequestBody(httpReq, runner)
-	res, err := runner.client.Do(httpReq)
+	client, err := runner.httpClient(req)
 	if err != nil {
 		return nil, err
 	}
+	client.Timeout = policy.Timeout
+	if runner.cookiesEnabled(req) {
+		client.Jar = runner.jar
+	}
+
+	var res *http.Response
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(policy.Backoff << uint(attempt-1))
+		}
 
-	runner.output.Echo("\nResponse ")
-	if res.StatusCode == 200 {
-		color.Green("Status: %d\n\n", res.StatusCode)
-	} else {
-		color.Red("Status: %d\n\n", res.StatusCode)
+		var httpReq *http.Request
+		httpReq, err = CreateHttpRequest(req, runner)
+		if err != nil {
+			return nil, err
+		}
+		// signed every attempt, after the request is final
+		err = runner.signRequest(httpReq)
+		if err != nil {
+			return nil, err
+		}
+		runner.Reporter.RequestSent(req, httpReq, attempt+1)
+
+		start := time.Now()
+		res, err = client.Do(withTiming(httpReq))
+		// the body is read for the reporters, and the timing ends with it
+		var body []byte
+		if err == nil {
+			body, err = ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = ioutil.NopCloser(bytes.NewReader(body))
+			ResponseTiming(res)
+		}
+		runner.Reporter.ResponseReceived(req, res, body, err)
+		if runner.result != nil {
+			runner.result.Attempts = append(runner.result.Attempts, newAttempt(res, err, time.Since(start)))
+		}
+		if attempt >= policy.Retries || !policy.ShouldRetry(res, err) {
+			break
+		}
+	}
+	if policy.Delay > 0 {
+		defer time.Sleep(policy.Delay)
+	}
+	if err != nil {
+		return nil, err
 	}
-	runner.output.Headers(res.Header)
 	return res, nil
 }
 
+// Methods the http methods of api header comments, matched case insensitively
+var Methods = []string{"GET", "POST", "DELETE", "PUT", "PATCH", "HEAD", "OPTIONS", "TRACE"}
+
+// customMethod custom verbs like PROPFIND must be upper case and followed by a path
+var customMethod = regexp.MustCompile(`^[A-Z][A-Z-]*$`)
+
+// isMethod whether the words start an api header comment like `// PROPFIND /files`
+func isMethod(method, path string) bool {
+	for _, item := range Methods {
+		if strings.EqualFold(item, method) {
+			return true
+		}
+	}
+	return customMethod.MatchString(method) && strings.HasPrefix(path, "/")
+}
+
+// AnnotationKeys the keys of the comments after an api header read as its annotations,
+// like `// retries: 3`
+var AnnotationKeys = map[string]bool{
+	"timeout":         true,
+	"retries":         true,
+	"retryOn":         true,
+	"backoff":         true,
+	"delay":           true,
+	"cookies":         true,
+	"snapshotIgnore":  true,
+	"proxy":           true,
+	"noProxy":         true,
+	"followRedirects": true,
+	"httpVersion":     true,
+	"keepAlive":       true,
+}
+
 // ParseAPIItems parse ap items from pica code
 func (runner *APIRunner) ParseAPIItems() error {
 	headers := VmMap2HttpHeaders(DefaultHeaders)
@@ -209,31 +419,48 @@ func (runner *APIRunner) ParseAPIItems() error {
 		line := runner.Block.Statements[index]
 		switch line := line.(type) {
 		case *funny.Comment:
-			text := strings.Trim(line.Value, " ")
-			texts := strings.Split(text, " ")
+			texts := strings.Fields(line.Value)
 			if len(texts) < 2 {
 				break
 			}
-			methods := []string{"GET", "POST", "DELETE", "PUT", "PATCH"}
-			flag := false
-			for _, item := range methods {
-				if strings.EqualFold(item, texts[0]) {
-					flag = true
+			if inited && strings.EqualFold(texts[0], "depends:") {
+				item := runner.APIItems[len(runner.APIItems)-1]
+				for _, name := range strings.Split(strings.Join(texts[1:], ","), ",") {
+					if name != "" {
+						item.Request.Depends = append(item.Request.Depends, name)
+					}
 				}
+				break
 			}
-			if flag {
+			// annotations of the api like `// timeout: 5s`, other comments stay comments
+			if key := strings.TrimSuffix(texts[0], ":"); inited && key != texts[0] && AnnotationKeys[key] {
+				item := runner.APIItems[len(runner.APIItems)-1]
+				item.Request.Annotations[key] = strings.Join(texts[1:], " ")
+				break
+			}
+			if isMethod(texts[0], texts[1]) {
 				inited = true
 				asserting = false
 				req := ApiRequest{
-					Method:  texts[0],
-					Url:     texts[1],
-					Headers: headers,
+					Method:      strings.ToUpper(texts[0]),
+					Url:         texts[1],
+					Headers:     headers,
+					Annotations: map[string]string{},
 				}
-				if len(texts) > 2 {
-					req.Name = texts[2]
+				// words after the path: name, description and @tags
+				var words []string
+				for _, word := range texts[2:] {
+					if strings.HasPrefix(word, "@") && len(word) > 1 {
+						req.Tags = append(req.Tags, word[1:])
+					} else {
+						words = append(words, word)
+					}
 				}
-				if len(texts) > 3 {
-					req.Description = texts[3]
+				if len(words) > 0 {
+					req.Name = words[0]
+				}
+				if len(words) > 1 {
+					req.Description = strings.Join(words[1:], " ")
 				}
 				apiItem := &ApiItem{
 					Request:  &req,
@@ -241,16 +468,10 @@ func (runner *APIRunner) ParseAPIItems() error {
 				}
 				runner.APIItems = append(runner.APIItems, apiItem)
 			}
-		case *funny.FunctionCall:
-			if line.Name == "assert" {
+		default:
+			if call, ok := line.(*funny.FunctionCall); ok && inited && ResponseFunctions[call.Name] {
 				asserting = true
 			}
-			if asserting {
-				item := runner.APIItems[len(runner.APIItems)-1]
-				item.Response.lines.Statements = append(item.Response.lines.Statements, line)
-				break
-			}
-		default:
 			if inited {
 				if asserting {
 					item := runner.APIItems[len(runner.APIItems)-1]
@@ -1,8 +1,62 @@
 package pica
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestApiRunner_Run(t *testing.T) {
 	runner := NewAPIRunnerFromFile("sample/pica.fun", nil, 0)
 	runner.Run()
 }
+
+func TestAPIRunner_Methods(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(data))
+		if r.Method == "OPTIONS" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(204)
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// HEAD /api/users/1 userExists
+// Response
+expectEqual(status, 204)
+
+// options /api/users preflight
+// Response
+expectHeader('Access-Control-Allow-Methods', 'GET, POST')
+
+// TRACE /api/trace trace
+
+// PROPFIND /dav/files listFiles
+headers = {
+    'Content-Type' = 'application/xml'
+}
+body = '<propfind/>'
+
+// MKCOL /dav/files/new createFolder
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, []string{
+		"HEAD /api/users/1 ",
+		"OPTIONS /api/users ",
+		"TRACE /api/trace ",
+		"PROPFIND /dav/files <propfind/>",
+		"MKCOL /dav/files/new ",
+	}, requests)
+	assert.Equal(t, "OPTIONS", runner.APIItems[1].Request.Method)
+}
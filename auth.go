@@ -0,0 +1,245 @@
+package pica
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jerloo/funny"
+)
+
+// Auth the `auth` block of a file or an api, applied to the Authorization header
+//
+//	auth = {
+//	    type = 'oauth2'
+//	    tokenUrl = 'https://auth.example.com/token'
+//	    clientId = 'pica'
+//	    clientSecret = 'secret'
+//	    scopes = ['users:read']
+//	}
+//
+// Types are basic with username and password, bearer with token, and oauth2 with the
+// client_credentials grant, or the password grant when username and password are given.
+//
+// Like headers, `auth` is a variable of the file: an api assigning it sets the auth of the
+// apis after it too, until one assigns another block, or `auth = {}` to send none.
+type Auth struct {
+	Type         string
+	Username     string
+	Password     string
+	Token        string
+	TokenUrl     string
+	ClientId     string
+	ClientSecret string
+	Scopes       []string
+	// Grant client_credentials or password
+	Grant string
+	// ClientAuth how oauth2 clients authenticate, header for basic auth or body for form params
+	ClientAuth string
+}
+
+// TokenExpiryDelta tokens expiring within the delta are refreshed before being used
+var TokenExpiryDelta = 10 * time.Second
+
+// newAuth parse the auth block, nil when absent or of type none
+func newAuth(val funny.Value) (*Auth, error) {
+	block, ok := val.(map[string]funny.Value)
+	if val == nil || (ok && len(block) == 0) {
+		return nil, nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupport type [%s], only support [map]", funny.Typing(val))
+	}
+	auth := &Auth{
+		Type:         strings.ToLower(authString(block, "type")),
+		Username:     authString(block, "username"),
+		Password:     authString(block, "password"),
+		Token:        authString(block, "token"),
+		TokenUrl:     authString(block, "tokenUrl"),
+		ClientId:     authString(block, "clientId"),
+		ClientSecret: authString(block, "clientSecret"),
+		Scopes:       stringList(block["scopes"]),
+		Grant:        authString(block, "grant"),
+		ClientAuth:   authString(block, "clientAuth"),
+	}
+	switch auth.Type {
+	case "none", "":
+		return nil, nil
+	case "basic":
+		if auth.Username == "" {
+			return nil, fmt.Errorf("basic auth requires username")
+		}
+	case "bearer":
+		if auth.Token == "" {
+			return nil, fmt.Errorf("bearer auth requires token")
+		}
+	case "oauth2":
+		if auth.TokenUrl == "" {
+			return nil, fmt.Errorf("oauth2 auth requires tokenUrl")
+		}
+		if auth.Grant == "" {
+			auth.Grant = "client_credentials"
+			if auth.Username != "" {
+				auth.Grant = "password"
+			}
+		}
+		if auth.Grant != "client_credentials" && auth.Grant != "password" {
+			return nil, fmt.Errorf("unsupport oauth2 grant [%s], only support [client_credentials][password]", auth.Grant)
+		}
+	default:
+		return nil, fmt.Errorf("unsupport auth type [%s], only support [basic][bearer][oauth2][none]", auth.Type)
+	}
+	return auth, nil
+}
+
+func authString(block map[string]funny.Value, key string) string {
+	if val, ok := block[key]; ok && val != nil {
+		return fmt.Sprint(val)
+	}
+	return ""
+}
+
+// applyAuth set the Authorization header of the `auth` block of the vm
+func (runner *APIRunner) applyAuth(req *ApiRequest, header http.Header) error {
+	auth, err := newAuth(runner.vm.LookupDefault("auth", nil))
+	if err != nil {
+		return fmt.Errorf("auth %s", err.Error())
+	}
+	if auth == nil {
+		return nil
+	}
+	switch auth.Type {
+	case "basic":
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		header.Set("Authorization", "Basic "+credentials)
+	case "bearer":
+		header.Set("Authorization", "Bearer "+auth.Token)
+	case "oauth2":
+		client, err := runner.httpClient(req)
+		if err != nil {
+			return err
+		}
+		token, err := runner.tokens.get(client, auth)
+		if err != nil {
+			return fmt.Errorf("auth oauth2 %s", err.Error())
+		}
+		header.Set("Authorization", token.authorization())
+	}
+	return nil
+}
+
+// oauth2Token a token of an oauth2 token endpoint
+type oauth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+
+	expiry time.Time
+}
+
+func (t *oauth2Token) valid() bool {
+	return t.AccessToken != "" && (t.expiry.IsZero() || time.Now().Add(TokenExpiryDelta).Before(t.expiry))
+}
+
+func (t *oauth2Token) authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// tokenCache the oauth2 tokens of a run, by endpoint, client, user and scopes
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2Token
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{tokens: map[string]*oauth2Token{}}
+}
+
+// get the cached token of auth, refreshed or fetched again when it expires
+func (c *tokenCache) get(client *http.Client, auth *Auth) (*oauth2Token, error) {
+	key := strings.Join([]string{auth.TokenUrl, auth.Grant, auth.ClientId, auth.Username, strings.Join(auth.Scopes, " ")}, "\n")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	token := c.tokens[key]
+	if token != nil && token.valid() {
+		return token, nil
+	}
+	if token != nil && token.RefreshToken != "" {
+		refreshed, err := fetchToken(client, auth, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {token.RefreshToken},
+		})
+		// fetch a new one when refreshing is refused
+		if err == nil {
+			if refreshed.RefreshToken == "" {
+				refreshed.RefreshToken = token.RefreshToken
+			}
+			c.tokens[key] = refreshed
+			return refreshed, nil
+		}
+	}
+	params := url.Values{"grant_type": {auth.Grant}}
+	if auth.Grant == "password" {
+		params.Set("username", auth.Username)
+		params.Set("password", auth.Password)
+	}
+	token, err := fetchToken(client, auth, params)
+	if err != nil {
+		return nil, err
+	}
+	c.tokens[key] = token
+	return token, nil
+}
+
+func fetchToken(client *http.Client, auth *Auth, params url.Values) (*oauth2Token, error) {
+	if len(auth.Scopes) > 0 {
+		params.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+	if auth.ClientAuth == "body" {
+		params.Set("client_id", auth.ClientId)
+		params.Set("client_secret", auth.ClientSecret)
+	}
+	req, err := http.NewRequest("POST", auth.TokenUrl, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if auth.ClientAuth != "body" && auth.ClientId != "" {
+		req.SetBasicAuth(url.QueryEscape(auth.ClientId), url.QueryEscape(auth.ClientSecret))
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token %s %s: %s", auth.TokenUrl, res.Status, data)
+	}
+	token := &oauth2Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("token %s: %s", auth.TokenUrl, err.Error())
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token %s: no access_token in %s", auth.TokenUrl, data)
+	}
+	if token.ExpiresIn > 0 {
+		token.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,149 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Auth(t *testing.T) {
+	var grants []string
+	var authorizations []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		grants = append(grants, grant)
+		token := map[string]interface{}{
+			"access_token": fmt.Sprintf("token%d", len(grants)),
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		}
+		switch grant {
+		case "client_credentials":
+			id, secret, _ := r.BasicAuth()
+			if id != "pica" || secret != "secret" || r.PostForm.Get("scope") != "users:read users:write" {
+				w.WriteHeader(401)
+				return
+			}
+		case "password":
+			if r.PostForm.Get("username") != "admin" || r.PostForm.Get("client_id") != "pica" {
+				w.WriteHeader(401)
+				return
+			}
+			// expires at once, so the next request refreshes it
+			token["expires_in"] = 1
+			token["refresh_token"] = "refresh"
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh" {
+				w.WriteHeader(401)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(token)
+	})
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "text/plain")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+auth = {
+    type = 'oauth2'
+    tokenUrl = '` + server.URL + `/token'
+    clientId = 'pica'
+    clientSecret = 'secret'
+    scopes = ['users:read', 'users:write']
+}
+
+// GET /api/1 first
+
+// GET /api/2 cached
+
+// GET /api/3 basic
+auth = {
+    type = 'basic'
+    username = 'admin'
+    password = 'pass'
+}
+
+// GET /api/4 bearer
+auth = {
+    type = 'bearer'
+    token = 'static'
+}
+
+// GET /api/5 password
+auth = {
+    type = 'oauth2'
+    tokenUrl = '` + server.URL + `/token'
+    clientId = 'pica'
+    clientAuth = 'body'
+    username = 'admin'
+    password = 'pass'
+}
+
+// GET /api/6 refreshed
+
+// GET /api/7 anonymous
+auth = {
+    type = 'none'
+}
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, []string{"client_credentials", "password", "refresh_token"}, grants)
+	assert.Equal(t, []string{
+		"Bearer token1",
+		"Bearer token1",
+		"Basic YWRtaW46cGFzcw==",
+		"Bearer static",
+		"Bearer token2",
+		"Bearer token3",
+		"",
+	}, authorizations)
+}
+
+func TestAPIRunner_AuthCarriesOver(t *testing.T) {
+	authorizations := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.URL.Path] = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /api/admin admin
+auth = {
+    type = 'bearer'
+    token = 'admin'
+}
+
+// GET /api/users users
+
+// GET /api/public public
+auth = {}
+
+// GET /api/health health
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, map[string]string{
+		"/api/admin": "Bearer admin",
+		// the auth of an api is sent by the apis after it
+		"/api/users":  "Bearer admin",
+		"/api/public": "",
+		"/api/health": "",
+	}, authorizations)
+}
@@ -4,6 +4,9 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -23,6 +26,8 @@ type Benchmark struct {
 	WaitPerReq time.Duration
 	//ShowProgress is set to true if it should display current stat when the benchmark is running/in progress.
 	ShowProgress bool
+	//Output is where the stats are printed, stdout by default
+	Output io.Writer
 
 	//waitGroup is used for tracking go routine executions
 	waitGroup sync.WaitGroup
@@ -64,6 +69,11 @@ type Benchmark struct {
 	//errors keeps track of all the errors encountered during the benchmark
 	errors map[string]errorStat
 
+	//timings is the sum of the phases of the traced requests, added by AddTiming
+	timings Timing
+	//timingCounter is the no.of traced requests
+	timingCounter uint64
+
 	mutex *sync.Mutex
 }
 
@@ -123,6 +133,8 @@ func New() *Benchmark {
 
 		errors: make(map[string]errorStat),
 
+		Output: os.Stdout,
+
 		mutex: &sync.Mutex{},
 	}
 
@@ -159,35 +171,74 @@ func (bA *Benchmark) Done(doneTime time.Duration, err error) {
 
 	if err != nil {
 		bA.errStat(err)
-		atomic.AddUint64(&bA.errorRequestTimer, timeConsumed)
+	}
+
+	//the slowest and fastest are compared and set together by the concurrent requests
+	bA.mutex.Lock()
+	if err != nil {
+		bA.errorRequestTimer += timeConsumed
 
 		if timeConsumed > bA.errorMaxReqTime {
-			atomic.StoreUint64(&bA.errorMaxReqTime, timeConsumed)
+			bA.errorMaxReqTime = timeConsumed
 		}
 
 		if timeConsumed < bA.errorMinReqTime {
-			atomic.StoreUint64(&bA.errorMinReqTime, timeConsumed)
+			bA.errorMinReqTime = timeConsumed
 		}
 	} else {
-		atomic.AddUint64(&bA.successRequestTimer, timeConsumed)
+		bA.successRequestTimer += timeConsumed
 		atomic.AddUint64(&bA.successCounter, 1)
 
 		if timeConsumed > bA.successMaxReqTime {
-			atomic.StoreUint64(&bA.successMaxReqTime, timeConsumed)
+			bA.successMaxReqTime = timeConsumed
 		}
 
 		if timeConsumed < bA.successMinReqTime {
-			atomic.StoreUint64(&bA.successMinReqTime, timeConsumed)
+			bA.successMinReqTime = timeConsumed
 		}
 	}
+	bA.mutex.Unlock()
 
 	bA.waitGroup.Done()
 }
 
+//AddTiming adds the phases of a traced request, like the Timing of an ApiResult, to the statistics
+func (bA *Benchmark) AddTiming(timing *Timing) {
+	if timing == nil {
+		return
+	}
+	bA.mutex.Lock()
+	bA.timings.DNS += timing.DNS
+	bA.timings.Connect += timing.Connect
+	bA.timings.TLS += timing.TLS
+	bA.timings.FirstByte += timing.FirstByte
+	bA.timings.Transfer += timing.Transfer
+	bA.timings.Total += timing.Total
+	bA.timingCounter++
+	bA.mutex.Unlock()
+}
+
+//RunAPIs runs the benchmark with a new runner for every request, like the runner of an api file and names.
+//A request fails when one of its apis fails, and the timing of every api is added to the statistics.
+func (bA *Benchmark) RunAPIs(newRunner func() *APIRunner) {
+	bA.Run(func() error {
+		runner := newRunner()
+		runner.Reporter = NewDotsReporter(ioutil.Discard, true)
+		err := runner.Run()
+		for _, result := range runner.Results {
+			bA.AddTiming(result.Timing)
+			if err == nil && !result.Passed {
+				err = fmt.Errorf("api %s failed %s", result.Name, result.Error)
+			}
+		}
+		return err
+	})
+}
+
 //Run runs the benchmark for the given function
 func (bA *Benchmark) Run(fn func() error) {
 	bA.benchStart = time.Now()
-	fmt.Println(
+	fmt.Fprintln(bA.Output,
 		"\nDuration              :", time.Millisecond*time.Duration(bA.BenchDuration),
 		"\nTotal requests        :", bA.TotalRequests,
 		"\nWait time per request :", bA.WaitPerReq,
@@ -210,11 +261,15 @@ func (bA *Benchmark) Run(fn func() error) {
 
 //PrintStat prints the stats available based on the given input params and the global variable values
 func (bA *Benchmark) PrintStat() {
-	if bA.ShowProgress == true && bA.requestCounter%bA.StatReqCount == 0 {
-		fmt.Println(
-			bA.requestCounter, " out of ", bA.TotalRequests, " done.",
-			" Success:", bA.successCounter,
-			" Errors:", bA.errorCounter)
+	requestCounter := atomic.LoadUint64(&bA.requestCounter)
+	if bA.ShowProgress == true && requestCounter%bA.StatReqCount == 0 {
+		bA.mutex.Lock()
+		errorCounter := bA.errorCounter
+		bA.mutex.Unlock()
+		fmt.Fprintln(bA.Output,
+			requestCounter, " out of ", bA.TotalRequests, " done.",
+			" Success:", atomic.LoadUint64(&bA.successCounter),
+			" Errors:", errorCounter)
 	}
 }
 
@@ -228,7 +283,7 @@ func (bA *Benchmark) Final() {
 	errorRatio := float64(bA.errorCounter) * float64(100) / float64(bA.requestCounter)
 
 	//Req completion will be printed inside this infinite for loop, as well as the app would wait
-	fmt.Println(
+	fmt.Fprintln(bA.Output,
 		"\n========================= Benchmark stats =========================\n",
 		"\nDone               :", time.Now(),
 		"\nTime to complete   :", time.Since(bA.benchStart),
@@ -239,7 +294,7 @@ func (bA *Benchmark) Final() {
 	)
 
 	if bA.successCounter > 0 {
-		fmt.Println(
+		fmt.Fprintln(bA.Output,
 			"\nAverage time per successful request :", time.Nanosecond*time.Duration(bA.successRequestTimer)/time.Duration(bA.successCounter),
 			"\nFastest                             :", time.Duration(time.Nanosecond*time.Duration(bA.successMinReqTime)),
 			"\nSlowest                             :", time.Duration(time.Nanosecond*time.Duration(bA.successMaxReqTime)),
@@ -247,19 +302,31 @@ func (bA *Benchmark) Final() {
 	}
 
 	if bA.errorCounter > 0 {
-		fmt.Println(
+		fmt.Fprintln(bA.Output,
 			"\nAverage time per failed request :", time.Nanosecond*time.Duration(bA.errorRequestTimer)/time.Duration(bA.errorCounter),
 			"\nFastest                         :", time.Duration(time.Nanosecond*time.Duration(bA.errorMinReqTime)),
 			"\nSlowest                         :", time.Duration(time.Nanosecond*time.Duration(bA.errorMaxReqTime)),
 		)
 	}
 
+	if bA.timingCounter > 0 {
+		count := time.Duration(bA.timingCounter)
+		fmt.Fprintln(bA.Output,
+			"\nAverage dns        :", bA.timings.DNS/count,
+			"\nAverage connect    :", bA.timings.Connect/count,
+			"\nAverage tls        :", bA.timings.TLS/count,
+			"\nAverage first byte :", bA.timings.FirstByte/count,
+			"\nAverage transfer   :", bA.timings.Transfer/count,
+			"\nAverage total      :", bA.timings.Total/count,
+		)
+	}
+
 	if len(bA.errors) > 0 {
-		println("\n\nError messages (" + strconv.Itoa(len(bA.errors)) + ")")
+		fmt.Fprintln(bA.Output, "\n\nError messages ("+strconv.Itoa(len(bA.errors))+")")
 		idx := 1
 		for _, item := range bA.errors {
-			println("\n "+strconv.Itoa(idx)+".", item.Message)
-			println("  Occurrences:", item.Count)
+			fmt.Fprintln(bA.Output, "\n "+strconv.Itoa(idx)+".", item.Message)
+			fmt.Fprintln(bA.Output, "  Occurrences:", item.Count)
 			idx++
 		}
 	}
@@ -0,0 +1,66 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchmark_RunAPIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	content := []byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /users getUsers
+// Response
+assert(status == 200)
+`)
+	buf := new(bytes.Buffer)
+	b := New()
+	b.Output = buf
+	b.TotalRequests = 3
+	b.BenchDuration = 30
+	b.ShowProgress = false
+	b.Init()
+	b.RunAPIs(func() *APIRunner {
+		return NewAPIRunnerFromContent(content)
+	})
+
+	assert.Equal(t, uint64(3), b.successCounter)
+	assert.Equal(t, uint64(3), b.timingCounter)
+	assert.Contains(t, buf.String(), "Average first byte :")
+	assert.Contains(t, buf.String(), "Average total      :")
+
+	failing := []byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /missing getMissing
+// Response
+assert(status == 200)
+`)
+	buf.Reset()
+	b = New()
+	b.Output = buf
+	b.TotalRequests = 2
+	b.BenchDuration = 20
+	b.ShowProgress = false
+	b.Init()
+	b.RunAPIs(func() *APIRunner {
+		return NewAPIRunnerFromContent(failing)
+	})
+
+	assert.Equal(t, uint64(2), b.errorCounter)
+	assert.Equal(t, uint64(2), b.timingCounter)
+	assert.Contains(t, buf.String(), "Average time per failed request :")
+	assert.Contains(t, buf.String(), "Occurrences: 2")
+}
@@ -3,74 +3,122 @@ package pica
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/jerloo/funny"
 	"github.com/pkg/errors"
 )
 
+// BodylessMethods send no body, unless the `body` variable is assigned
+var BodylessMethods = map[string]bool{
+	"GET":     true,
+	"HEAD":    true,
+	"DELETE":  true,
+	"OPTIONS": true,
+	"TRACE":   true,
+}
+
 func CreateHttpRequest(req *ApiRequest, runner *APIRunner) (httpReq *http.Request, err error) {
-	var bodyParams map[string]funny.Value
-	if req.Method != "GET" && req.Method != "DELETE" {
-		bodyParams = runner.vm.Lookup(strings.ToLower(req.Method)).(map[string]funny.Value)
-	}
-	headers := runner.vm.LookupDefault("headers", nil).(map[string]funny.Value)
-	contentType := "unknow content type"
+	headers, _ := runner.vm.LookupDefault("headers", nil).(map[string]funny.Value)
+	var header http.Header
 	if headers != nil {
-		contentType = headers["Content-Type"].(string)
+		header = VmMap2HttpHeaders(headers)
 	} else {
-		contentType = req.Headers["Content-Type"][0]
+		header = req.Headers.Clone()
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	if err := runner.applyAuth(req, header); err != nil {
+		return nil, err
+	}
+
+	targetUrl, err := getTargetURL(req, runner)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if val := requestBody(req, runner); val != nil {
+		var contentType string
+		body, contentType, err = encodeBody(header.Get("Content-Type"), val)
+		if err != nil {
+			return nil, fmt.Errorf("body of %s %s: %s", req.Method, req.Url, err.Error())
+		}
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+	}
+	httpReq, err = http.NewRequest(req.Method, targetUrl, body)
+	if err != nil {
+		return nil, err
 	}
+	httpReq.Header = header
+	return
+}
 
-	switch contentType {
-	case "application/x-www-form-urlencoded":
-		fmt.Printf("create %s", "application/x-www-form-urlencoded")
-		httpReq, err = createFormUrlEncodedRequest(req, runner, bodyParams)
+// requestBody the body of req, the `body` assigned in the request lines of the api, or
+// the variable named by the method like `post`. Bodyless methods only use `body`.
+func requestBody(req *ApiRequest, runner *APIRunner) funny.Value {
+	if req.body != nil || BodylessMethods[strings.ToUpper(req.Method)] {
+		return req.body
+	}
+	return runner.vm.LookupDefault(strings.ToLower(req.Method), nil)
+}
+
+// encodeBody encode val by the content type. Strings and bytes are sent as they are,
+// maps are encoded as json, form, multipart or xml. The content type returned, if not
+// empty, replaces the header, like multipart ones with the boundary.
+func encodeBody(contentType string, val funny.Value) (io.Reader, string, error) {
+	switch val := val.(type) {
+	case []byte:
+		return bytes.NewReader(val), "", nil
+	case string:
+		return strings.NewReader(val), "", nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		data, err := json.Marshal(normalize(val))
+		return bytes.NewReader(data), "", err
+	case mediaType == "application/x-www-form-urlencoded":
+		params, err := bodyParams(val)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		break
-	case "multipart/form-data":
-		httpReq, err = createFormDataRequest(req, runner, bodyParams)
+		return encodeFormUrlEncoded(params)
+	case mediaType == "multipart/form-data":
+		params, err := bodyParams(val)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		break
-	case "application/json":
-		httpReq, err = createJsonRequest(req, runner, bodyParams)
+		return encodeFormData(params)
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		params, err := bodyParams(val)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		break
+		return encodeXml(params)
 	default:
-		if req.Method == "GET" || req.Method == "DELETE" {
-			targetUrl, err := getTargetURL(req, runner)
-			if err != nil {
-				return nil, err
-			}
-			httpReq, err = http.NewRequest(req.Method, targetUrl, nil)
-			if err != nil {
-				return nil, err
-			}
-		}
-		return nil, errors.New("unknow http method")
-
+		return nil, "", fmt.Errorf("unsupport %s body of content type [%s], assign a string or file(path) instead", typeName(val), contentType)
 	}
+}
 
-	if headers != nil {
-		httpReq.Header = VmMap2HttpHeaders(headers)
-	} else {
-		httpReq.Header = req.Headers
+func bodyParams(val funny.Value) (map[string]funny.Value, error) {
+	params, ok := val.(map[string]funny.Value)
+	if !ok {
+		return nil, fmt.Errorf("unsupport body type [%s], only support [map][string][bytes]", typeName(val))
 	}
-	return
+	return params, nil
 }
 
 func getValue(val funny.Value) string {
@@ -91,21 +139,15 @@ func getTargetURL(req *ApiRequest, runner *APIRunner) (string, error) {
 	return targetUrl, err
 }
 
-func createFormUrlEncodedRequest(req *ApiRequest, runner *APIRunner, bodyParams map[string]funny.Value) (*http.Request, error) {
+func encodeFormUrlEncoded(bodyParams map[string]funny.Value) (io.Reader, string, error) {
 	v := url.Values{}
 	for key, val := range bodyParams {
 		v.Set(key, getValue(val))
 	}
-	u := ioutil.NopCloser(strings.NewReader(v.Encode()))
-	fmt.Printf("application/x-www-form-urlencoded %s\n", v.Encode())
-	targetUrl, err := getTargetURL(req, runner)
-	if err != nil {
-		return nil, err
-	}
-	return http.NewRequest(req.Method, targetUrl, u)
+	return strings.NewReader(v.Encode()), "", nil
 }
 
-func createFormDataRequest(req *ApiRequest, runner *APIRunner, bodyParams map[string]funny.Value) (*http.Request, error) {
+func encodeFormData(bodyParams map[string]funny.Value) (io.Reader, string, error) {
 	buf := new(bytes.Buffer)
 	writer := multipart.NewWriter(buf)
 	for key, val := range bodyParams {
@@ -115,38 +157,89 @@ func createFormDataRequest(req *ApiRequest, runner *APIRunner, bodyParams map[st
 			_, filename := filepath.Split(fullFileName)
 			formFile, err := writer.CreateFormFile(key, filename)
 			if err != nil {
-				return nil, errors.New("Create form file failed: %s\n")
+				return nil, "", errors.Errorf("Create form file failed: %s\n", err)
 			}
 
 			srcFile, err := os.Open(fullFileName)
 			if err != nil {
-				return nil, errors.New("%Open source file failed: s\n")
+				return nil, "", errors.Errorf("Open source file failed: %s\n", err)
 			}
 			defer srcFile.Close()
 			_, err = io.Copy(formFile, srcFile)
 			if err != nil {
-				return nil, errors.New("Write to form file falied: %s\n")
+				return nil, "", errors.Errorf("Write to form file falied: %s\n", err)
 			}
 		} else {
 			writer.WriteField(key, v)
 		}
 	}
 	writer.Close()
-	targetUrl, err := getTargetURL(req, runner)
-	if err != nil {
-		return nil, err
-	}
-	return http.NewRequest(req.Method, targetUrl, buf)
+	return buf, writer.FormDataContentType(), nil
 }
 
-func createJsonRequest(req *ApiRequest, runner *APIRunner, bodyParams map[string]funny.Value) (*http.Request, error) {
-	targetUrl, err := getTargetURL(req, runner)
-	if err != nil {
-		return nil, err
+// encodeXml encode the map of a single root element, the reverse of the xml decoding:
+// keys prefixed by @ are attributes, #text is the text and lists are repeated elements
+func encodeXml(bodyParams map[string]funny.Value) (io.Reader, string, error) {
+	if len(bodyParams) != 1 {
+		return nil, "", fmt.Errorf("xml body requires a single root element but got %d", len(bodyParams))
 	}
-	jsonContent, err := json.Marshal(bodyParams)
-	if err != nil {
-		return nil, err
+	buf := bytes.NewBufferString(xml.Header)
+	encoder := xml.NewEncoder(buf)
+	for name, val := range bodyParams {
+		if err := encodeXmlElement(encoder, name, val); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, "", err
+	}
+	return buf, "", nil
+}
+
+func encodeXmlElement(encoder *xml.Encoder, name string, val interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	switch val := normalize(val).(type) {
+	case []interface{}:
+		for _, item := range val {
+			if err := encodeXmlElement(encoder, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if strings.HasPrefix(key, "@") {
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: key[1:]}, Value: fmt.Sprint(val[key])})
+			}
+		}
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		if text, ok := val["#text"]; ok {
+			if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(text))); err != nil {
+				return err
+			}
+		}
+		for _, key := range keys {
+			if strings.HasPrefix(key, "@") || key == "#text" {
+				continue
+			}
+			if err := encodeXmlElement(encoder, key, val[key]); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case nil:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		return encoder.EncodeToken(start.End())
+	default:
+		return encoder.EncodeElement(fmt.Sprint(val), start)
 	}
-	return http.NewRequest(req.Method, targetUrl, bytes.NewBuffer(jsonContent))
 }
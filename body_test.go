@@ -0,0 +1,105 @@
+package pica
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_RequestBodies(t *testing.T) {
+	type received struct {
+		contentType string
+		body        string
+	}
+	var mu sync.Mutex
+	requests := map[string]received{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path] = received{r.Header.Get("Content-Type"), string(data)}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica-body")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "payload.bin"), []byte{0, 1, 2, 255}, 0644))
+	filename := filepath.Join(dir, "pica.fun")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+
+// GET /get
+headers = {
+    'Content-Type' = 'application/json'
+}
+
+// POST /xml
+headers = {
+    'Content-Type' = 'application/xml'
+}
+post = {
+    user = {
+        '@id' = 1
+        name = 'pica'
+        tag = ['a', 'b']
+    }
+}
+
+// PUT /text
+headers = {
+    'Content-Type' = 'text/plain'
+}
+put = 'hello'
+
+// POST /binary
+headers = {
+    'Content-Type' = 'application/octet-stream'
+}
+post = {}
+body = file('payload.bin')
+
+// PATCH /custom
+headers = {
+    'Content-Type' = 'application/vnd.pica.v1'
+}
+patch = 'raw'
+
+// POST /multipart
+headers = {
+    'Content-Type' = 'multipart/form-data'
+}
+post = {
+    name = 'pica'
+}
+
+// DELETE /delete
+headers = {
+    'Content-Type' = 'application/json'
+}
+`), 0644))
+
+	runner := NewAPIRunnerFromFile(filename, []string{}, 0)
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+
+	assert.Equal(t, "", requests["GET /get"].body)
+	assert.Equal(t, "", requests["DELETE /delete"].body)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<user id="1"><name>pica</name><tag>a</tag><tag>b</tag></user>`, requests["POST /xml"].body)
+	assert.Equal(t, "\x00\x01\x02\xff", requests["POST /binary"].body)
+	assert.Equal(t, "hello", requests["PUT /text"].body)
+	assert.Equal(t, "text/plain", requests["PUT /text"].contentType)
+	assert.Equal(t, "raw", requests["PATCH /custom"].body)
+	assert.Equal(t, "application/vnd.pica.v1", requests["PATCH /custom"].contentType)
+	assert.Contains(t, requests["POST /multipart"].contentType, "multipart/form-data; boundary=")
+	assert.Contains(t, requests["POST /multipart"].body, `name="name"`)
+}
@@ -1,6 +1,15 @@
 package pica
 
 import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
 	"github.com/icrowley/fake"
 	"github.com/jerloo/funny"
 )
@@ -34,3 +43,174 @@ func Words(interpreter *funny.Funny, args []funny.Value) funny.Value {
 func Domain(interpreter *funny.Funny, args []funny.Value) funny.Value {
 	return funny.Value(fake.DomainZone())
 }
+
+// JsonPath builtin function like jsonpath('$.items[?(@.age > 18)].name') queries the json of
+// the response, or the document given as the second argument.
+// Paths starting with . or [ are relative to the root, like jsonpath('.items[0].id')
+func JsonPath(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if len(args) == 0 || len(args) > 2 {
+		panic("jsonpath(path, [document]) requires the path")
+	}
+	path, ok := args[0].(string)
+	if !ok {
+		panic("jsonpath path must be a string")
+	}
+	if strings.HasPrefix(path, ".") || strings.HasPrefix(path, "[") {
+		path = "$" + path
+	}
+	doc := interpreter.LookupDefault("json", nil)
+	if len(args) == 2 {
+		doc = args[1]
+	}
+	result, err := jsonPathLanguage.Evaluate(path, normalize(doc))
+	if err != nil {
+		panic(fmt.Sprintf("jsonpath %s error %s", path, err.Error()))
+	}
+	return funny.Value(result)
+}
+
+// jsonPathLanguage jsonpath with the full gval language, for filters like ?(@.age > 18)
+var jsonPathLanguage = gval.Full(jsonpath.Language())
+
+// XPath builtin function like xpath('//user[@id="1"]/name') evaluates the xpath expression on the
+// body of the response, or the xml given as the second argument. Node sets become lists of
+// their texts, numbers, strings and booleans are returned as they are.
+func XPath(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if len(args) == 0 || len(args) > 2 {
+		panic("xpath(expr, [document]) requires the expression")
+	}
+	expr, ok := args[0].(string)
+	if !ok {
+		panic("xpath expression must be a string")
+	}
+	var body string
+	if len(args) == 2 {
+		body = fmt.Sprint(args[1])
+	} else {
+		switch val := interpreter.LookupDefault("body", nil).(type) {
+		case []byte:
+			body = string(val)
+		case string:
+			body = val
+		}
+	}
+	doc, err := xmlquery.Parse(strings.NewReader(body))
+	if err != nil {
+		panic(fmt.Sprintf("xpath parse xml error %s", err.Error()))
+	}
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		panic(fmt.Sprintf("xpath %s error %s", expr, err.Error()))
+	}
+	switch result := compiled.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		texts := []interface{}{}
+		for result.MoveNext() {
+			texts = append(texts, result.Current().Value())
+		}
+		return funny.Value(texts)
+	case float64:
+		// the vm has no float literals, whole numbers like count() become ints
+		if result == float64(int(result)) {
+			return funny.Value(int(result))
+		}
+		return funny.Value(result)
+	default:
+		return funny.Value(result)
+	}
+}
+
+// File builtin function like file('payload.bin') reads the bytes of a file relative to
+// the pica file, to be sent as a raw body like `body = file('payload.bin')`
+func (runner *APIRunner) File(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if len(args) != 1 {
+		panic(fmt.Sprintf("file(path) requires 1 argument but got %d", len(args)))
+	}
+	path, ok := args[0].(string)
+	if !ok {
+		panic("file path must be a string")
+	}
+	data, err := ioutil.ReadFile(runner.resolvePath(path))
+	if err != nil {
+		panic(fmt.Sprintf("file %s", err.Error()))
+	}
+	return funny.Value(data)
+}
+
+// ResponseFunctions calling one of them starts the response block of an api
+var ResponseFunctions = map[string]bool{
+	"assert":    true,
+	"waitUntil": true,
+}
+
+// WaitUntil builtin function like waitUntil(json.state == 'done', 30, 2) re-sends the request of
+// the api every 2 seconds until the condition holds, or fails after 30 seconds.
+// status, header, body and json are bound again after every request.
+func (runner *APIRunner) WaitUntil(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	call := findCall(runner.statement, "waitUntil")
+	if call == nil || runner.item == nil || len(args) == 0 {
+		panic("waitUntil(condition, [timeout], [interval]) must be called in the response block of an api")
+	}
+	timeout, interval := 30*time.Second, time.Second
+	if len(args) > 1 {
+		timeout = secondsValue(args[1])
+	}
+	if len(args) > 2 {
+		interval = secondsValue(args[2])
+	}
+
+	deadline := time.Now().Add(timeout)
+	condition := args[0]
+	for {
+		if ok, _ := condition.(bool); ok {
+			return funny.Value(true)
+		}
+		if time.Now().Add(interval).After(deadline) {
+			panic(funny.P(fmt.Sprintf("waitUntil %s still false after %s", call.Parameters[0].String(), timeout), call.Position))
+		}
+		time.Sleep(interval)
+		if err := runner.send(runner.item); err != nil {
+			panic(err)
+		}
+		condition = interpreter.EvalExpression(call.Parameters[0])
+	}
+}
+
+// secondsValue seconds for numbers, or strings like 500ms and 2s
+func secondsValue(val funny.Value) time.Duration {
+	if s, ok := val.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			panic(err)
+		}
+		return d
+	}
+	seconds, err := intValue(val)
+	if err != nil {
+		panic(err)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// findCall find the call of function name in statement
+func findCall(statement funny.Statement, name string) *funny.FunctionCall {
+	switch statement := statement.(type) {
+	case *funny.FunctionCall:
+		if statement.Name == name {
+			return statement
+		}
+		for _, param := range statement.Parameters {
+			if call := findCall(param, name); call != nil {
+				return call
+			}
+		}
+	case *funny.Assign:
+		return findCall(statement.Value, name)
+	case *funny.BinaryExpression:
+		if call := findCall(statement.Left, name); call != nil {
+			return call
+		}
+		return findCall(statement.Right, name)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_WaitUntil(t *testing.T) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		if count < 3 {
+			w.Write([]byte(`{"state": "running"}`))
+		} else {
+			w.Write([]byte(`{"state": "done"}`))
+		}
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /api/jobs/1 getJob
+query = {}
+// Response
+waitUntil(json.state == 'done', 5, '10ms')
+assert(status == 200)
+
+// GET /api/jobs/2 getJob2
+query = {}
+// Response
+waitUntil(json.state == 'failed', '30ms', '10ms')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.NotNil(t, err)
+	assert.True(t, runner.Results[0].Passed, runner.Results[0].Error)
+	assert.Equal(t, 3, len(runner.Results[0].Attempts))
+	assert.Contains(t, runner.Results[1].Error, "waitUntil json.state == 'failed' still false after 30ms")
+}
+
+func TestAPIRunner_JsonPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/users":
+			w.Write([]byte(`{"items": [{"name": "a", "age": 12}, {"name": "b", "age": 20}, {"name": "c", "age": 30}]}`))
+		case "/api/tags":
+			w.Write([]byte(`["x", "y"]`))
+		default:
+			w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /api/users listUsers
+query = {}
+// Response
+expectEqual(jsonpath('$.items[?(@.age > 18)].name'), ['b', 'c'])
+expectEqual(jsonpath('.items[0].age'), 12)
+name = jsonpath('$.items[2].name')
+
+// GET /api/tags listTags
+query = {}
+// Response
+expectEqual(json, ['x', 'y'])
+expectEqual(jsonpath('$[1]'), 'y')
+
+// GET /api/users/<name> getUser
+query = {}
+// Response
+expectEqual(json.path, '/api/users/c')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.Nil(t, err)
+}
@@ -16,29 +16,38 @@ limitations under the License.
 package cmd
 
 import (
+	"github.com/jerloo/pica"
 	"github.com/spf13/cobra"
 )
 
+var (
+	benchRequests uint64
+	benchDuration uint64
+	benchProgress bool
+)
+
 // benchCmd represents the bench command
 var benchCmd = &cobra.Command{
-	Use:   "bench",
+	Use:   "bench [file] [apiNames...]",
 	Short: "Benchmark apis.",
+	Long: `Benchmark apis.
+
+Every request of the benchmark runs the apis of the file, or the apis named, with a new runner.
+The stats have the time per request and the averages of the dns, connect, tls, first byte
+and transfer timing of the apis.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Returns a new Benchmark pointer with all the defaults assigned
-		// b := New()
-		// // time to wait before firing the consequent request
-		// // WaitPerReq = time.Millisecond * 1
-		// // print available stats while the benchmark is running
-		// b.ShowProgress = true
-		// // Total number of requests to fire
-		// b.TotalRequests = 10
-		// // Duration in which all the requests have to be finished firing (in milliseconds).
-		// b.BenchDuration = 7300
-
-		// // Updates all the necessary fields according to the configuration provided
-		// b.Init()
-		// // Run the benchmark
-		// b.Run(test)
+		if len(args) == 0 {
+			return
+		}
+		file := args[0]
+		b := pica.New()
+		b.TotalRequests = benchRequests
+		b.BenchDuration = benchDuration
+		b.ShowProgress = benchProgress
+		b.Init()
+		b.RunAPIs(func() *pica.APIRunner {
+			return pica.NewAPIRunnerFromFile(file, args[1:], 0)
+		})
 	},
 }
 
@@ -54,4 +63,7 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// benchCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	benchCmd.Flags().Uint64Var(&benchRequests, "requests", 200, "total number of requests to fire")
+	benchCmd.Flags().Uint64Var(&benchDuration, "duration", 1000, "duration over which all the requests are fired in milliseconds")
+	benchCmd.Flags().BoolVar(&benchProgress, "progress", true, "print the stats while the benchmark is running")
 }
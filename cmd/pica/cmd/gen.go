@@ -50,5 +50,5 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// genCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-	genCmd.Flags().StringVar(&from, "from", "postman", "support postman, swagger2")
+	genCmd.Flags().StringVar(&from, "from", "postman", "support postman, swagger2, har, curl")
 }
@@ -16,17 +16,67 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/jerloo/pica"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listJSON     bool
+	listMethod   string
+	listPathGlob string
+	listTags     []string
+)
+
 // listCmd represents the list command
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [files...]",
 	Short: "List all api names.",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("list called")
+		if len(args) == 0 {
+			args = []string{"pica.fun"}
+		}
+		selector := &pica.Selector{
+			Method:   listMethod,
+			PathGlob: listPathGlob,
+			Tags:     listTags,
+		}
+		var files []*pica.FileInfo
+		for _, filename := range args {
+			info, err := pica.ListAPIs(filename, selector)
+			if err != nil {
+				panic(err)
+			}
+			files = append(files, info)
+		}
+
+		if listJSON {
+			data, err := json.MarshalIndent(files, "", "  ")
+			if err != nil {
+				panic(err)
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, info := range files {
+			fmt.Fprintf(w, "%s\n", info.File)
+			for _, api := range info.APIs {
+				var tags []string
+				for _, tag := range api.Tags {
+					tags = append(tags, "@"+tag)
+				}
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", api.Method, api.Path, api.Name, api.Description, strings.Join(tags, " "))
+			}
+			fmt.Fprintf(w, "  %s\n\n", info.Summary())
+		}
+		w.Flush()
 	},
 }
 
@@ -42,4 +92,8 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// listCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "print as json")
+	listCmd.Flags().StringVar(&listMethod, "method", "", "only list apis of this http method")
+	listCmd.Flags().StringVar(&listPathGlob, "path-glob", "", "only list apis whose path matches the glob, like /api/users/*")
+	listCmd.Flags().StringSliceVar(&listTags, "tag", nil, "only list apis with all of the tags")
 }
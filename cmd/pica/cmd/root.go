@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jerloo/pica"
 	"github.com/spf13/cobra"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -70,6 +71,8 @@ func init() {
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	pica.DefaultOutput.Debug = debug
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -16,21 +16,65 @@ limitations under the License.
 package cmd
 
 import (
+	"strings"
+
 	"github.com/jerloo/pica"
 	"github.com/spf13/cobra"
 )
 
+var (
+	runSkip     []string
+	runTags     []string
+	runMethod   string
+	runPathGlob string
+	runParallel int
+	runDelay    int
+
+	runSnapshot        bool
+	runUpdateSnapshots bool
+	runReporters       []string
+	runReports         []string
+	runHar             string
+	runShowSecrets     bool
+)
+
 // runCmd represents the run command
 var runCmd = &cobra.Command{
-	Use:   "run",
+	Use:   "run [file] [apiNames...]",
 	Short: "Run an api file.",
+	Long: `Run an api file.
+
+Api names can be exact names, globs like user* or regular expressions like user.*,
+and are combined with the --tag, --method, --path-glob and --skip filters.
+
+Reporters are pretty, dots, quiet, jsonl, junit, tap, html and har, written to stdout or to
+a file like --reporter junit=report.xml, and can be combined by repeating the flag.
+--report html writes a single file html report to pica-report.html, or to the file
+of --report html=out.html, and --har out.har records every request and response
+into an HTTP Archive file.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			return
 		}
 		file := args[0]
-		apiRunner := pica.NewAPIRunnerFromFile(file, nil, 0)
-		err := apiRunner.Run()
+		apiRunner := pica.NewAPIRunnerFromFile(file, args[1:], runDelay)
+		apiRunner.Selector = &pica.Selector{
+			Skip:     runSkip,
+			Method:   runMethod,
+			PathGlob: runPathGlob,
+			Tags:     runTags,
+		}
+		apiRunner.Parallel = runParallel
+		apiRunner.SnapshotAll = runSnapshot
+		apiRunner.UpdateSnapshots = runUpdateSnapshots
+		pica.ShowSecrets = runShowSecrets
+		reporter, closeReporters, err := pica.ParseReporters(reportSpecs(runReporters, runReports, runHar), debug)
+		if err != nil {
+			panic(err)
+		}
+		defer closeReporters()
+		apiRunner.Reporter = reporter
+		err = apiRunner.Run()
 		if err != nil {
 			panic(err)
 		}
@@ -49,4 +93,32 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// runCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	runCmd.Flags().StringSliceVar(&runSkip, "skip", nil, "api names to skip, globs and regular expressions supported")
+	runCmd.Flags().StringSliceVar(&runTags, "tag", nil, "only run apis with all of the tags")
+	runCmd.Flags().StringVar(&runMethod, "method", "", "only run apis of this http method")
+	runCmd.Flags().IntVar(&runDelay, "delay", 0, "delay after one api request in milliseconds")
+	runCmd.Flags().IntVar(&runParallel, "parallel", 1, "max count of apis running at the same time, apis only share variables through depends")
+	runCmd.Flags().StringVar(&runPathGlob, "path-glob", "", "only run apis whose path matches the glob, like /api/users/*")
+	runCmd.Flags().BoolVar(&runSnapshot, "snapshot", false, "compare the response of every api with its snapshot in __snapshots__")
+	runCmd.Flags().BoolVar(&runUpdateSnapshots, "update-snapshots", false, "write the changed snapshots instead of failing")
+	runCmd.Flags().StringSliceVar(&runReporters, "reporter", []string{"pretty"}, "reporters of the run like dots or junit=report.xml")
+	runCmd.Flags().StringSliceVar(&runReports, "report", nil, "report files of the run like html or html=out.html")
+	runCmd.Flags().StringVar(&runHar, "har", "", "record the requests and responses of the run into a har file")
+	runCmd.Flags().BoolVar(&runShowSecrets, "show-secrets", false, "write the authorization, cookie and signature headers into report files as they are")
+}
+
+// reportSpecs the reporter specs with the reports, written to pica-report.<name> without a file,
+// and the har file
+func reportSpecs(reporters, reports []string, har string) []string {
+	specs := append([]string{}, reporters...)
+	for _, report := range reports {
+		if !strings.Contains(report, "=") {
+			report += "=pica-report." + report
+		}
+		specs = append(specs, report)
+	}
+	if har != "" {
+		specs = append(specs, "har="+har)
+	}
+	return specs
 }
@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jerloo/pica"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectFile     string
+	taskEnv         string
+	taskReporters   []string
+	taskReports     []string
+	taskHar         string
+	taskShowSecrets bool
+)
+
+// taskCmd represents the task command
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Run or list the named tasks of pica.json.",
+}
+
+// taskRunCmd represents the task run command
+var taskRunCmd = &cobra.Command{
+	Use:   "run [task]",
+	Short: "Run a named task.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := pica.LoadProject(projectFile)
+		if err != nil {
+			panic(err)
+		}
+		pica.ShowSecrets = taskShowSecrets
+		reporter, closeReporters, err := pica.ParseReporters(reportSpecs(taskReporters, taskReports, taskHar), debug)
+		if err != nil {
+			panic(err)
+		}
+		defer closeReporters()
+		project.Reporter = reporter
+		result, err := project.RunTask(args[0], taskEnv)
+		if result != nil {
+			fmt.Printf("\nTask [%s] finished in %s, [%d] passed, [%d] failed\n", args[0], result.Duration, result.Passed, result.Failed)
+		}
+		if err != nil {
+			panic(err)
+		}
+	},
+}
+
+// taskListCmd represents the task list command
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List named tasks.",
+	Run: func(cmd *cobra.Command, args []string) {
+		project, err := pica.LoadProject(projectFile)
+		if err != nil {
+			panic(err)
+		}
+		var names []string
+		for name := range project.Tasks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lastRunAt := "never"
+			if result, ok := project.Results[name]; ok {
+				lastRunAt = fmt.Sprintf("%s [%d] passed [%d] failed", result.LastRunAt, result.Passed, result.Failed)
+			}
+			fmt.Printf("%s\t%d steps\t%s\n", name, len(project.Tasks[name]), lastRunAt)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(taskCmd)
+	taskCmd.AddCommand(taskRunCmd)
+	taskCmd.AddCommand(taskListCmd)
+
+	taskCmd.PersistentFlags().StringVar(&projectFile, "project", pica.ProjectFile, "project manifest")
+	taskRunCmd.Flags().StringVar(&taskEnv, "env", "", "environment of the project to use")
+	taskRunCmd.Flags().StringSliceVar(&taskReporters, "reporter", []string{"pretty"}, "reporters of the task like dots or junit=report.xml")
+	taskRunCmd.Flags().StringSliceVar(&taskReports, "report", nil, "report files of the task like html or html=out.html")
+	taskRunCmd.Flags().StringVar(&taskHar, "har", "", "record the requests and responses of the task into a har file")
+	taskRunCmd.Flags().BoolVar(&taskShowSecrets, "show-secrets", false, "write the authorization, cookie and signature headers into report files as they are")
+}
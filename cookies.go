@@ -0,0 +1,103 @@
+package pica
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/jerloo/funny"
+)
+
+// CookieJar the cookies of one run, shared by the apis of the run and cleared by clearCookies()
+type CookieJar struct {
+	mu  sync.RWMutex
+	jar *cookiejar.Jar
+}
+
+// NewCookieJar create an empty cookie jar
+func NewCookieJar() *CookieJar {
+	c := &CookieJar{}
+	c.Clear()
+	return c
+}
+
+// SetCookies implements http.CookieJar
+func (c *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.jar.SetCookies(u, cookies)
+}
+
+// Cookies implements http.CookieJar
+func (c *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.jar.Cookies(u)
+}
+
+// Clear remove all the cookies
+func (c *CookieJar) Clear() {
+	jar, _ := cookiejar.New(nil)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jar = jar
+}
+
+// cookiesEnabled whether the api uses the cookie jar, disabled by `// cookies: off`
+func (runner *APIRunner) cookiesEnabled(req *ApiRequest) bool {
+	switch strings.ToLower(req.Annotations["cookies"]) {
+	case "off", "false", "no":
+		return false
+	}
+	return runner.jar != nil
+}
+
+// cookiesMap the cookies of the jar for the url of res, overridden by those set by res
+func (runner *APIRunner) cookiesMap(req *ApiRequest, res *http.Response) map[string]funny.Value {
+	cookies := make(map[string]funny.Value)
+	if runner.cookiesEnabled(req) && res.Request != nil {
+		for _, cookie := range runner.jar.Cookies(res.Request.URL) {
+			cookies[cookie.Name] = cookie.Value
+		}
+	}
+	for _, cookie := range res.Cookies() {
+		cookies[cookie.Name] = cookie.Value
+	}
+	return cookies
+}
+
+// SetCookie builtin function like setCookie('session', 'abc', [url]) sets a cookie in the jar
+// for the url, the baseUrl by default
+func (runner *APIRunner) SetCookie(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if len(args) < 2 || len(args) > 3 {
+		panic(fmt.Sprintf("setCookie(name, value, [url]) requires 2 or 3 arguments but got %d", len(args)))
+	}
+	rawURL, _ := interpreter.LookupDefault("baseUrl", nil).(string)
+	if len(args) == 3 {
+		rawURL = fmt.Sprint(args[2])
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		panic(fmt.Sprintf("setCookie invalid url [%s]", rawURL))
+	}
+	if runner.jar == nil {
+		panic("setCookie the cookie jar is disabled")
+	}
+	runner.jar.SetCookies(u, []*http.Cookie{{
+		Name:  fmt.Sprint(args[0]),
+		Value: fmt.Sprint(args[1]),
+		Path:  "/",
+	}})
+	return nil
+}
+
+// ClearCookies builtin function like clearCookies() removes all the cookies of the jar
+func (runner *APIRunner) ClearCookies(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if runner.jar != nil {
+		runner.jar.Clear()
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Cookies(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		}
+		var names []string
+		for _, cookie := range r.Cookies() {
+			names = append(names, cookie.Name+"="+cookie.Value)
+		}
+		sort.Strings(names)
+		received = append(received, r.URL.Path+" "+strings.Join(names, ","))
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// POST /login login
+post = {}
+// Response
+expectEqual(cookies.session, 'abc')
+
+// GET /me me
+setCookie('theme', 'dark')
+// Response
+expectEqual(cookies.theme, 'dark')
+
+// GET /public anonymous
+// cookies: off
+
+// GET /logout logout
+clearCookies()
+
+// GET /me meAgain
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, []string{
+		"/login ",
+		"/me session=abc,theme=dark",
+		"/public ",
+		"/logout ",
+		"/me ",
+	}, received)
+}
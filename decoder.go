@@ -0,0 +1,188 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/jerloo/funny"
+	"gopkg.in/yaml.v3"
+)
+
+// Decoder decodes the response bodies of some content types into the vm variable Name
+type Decoder struct {
+	// Name of the variable bound to the decoded body, like json or xml
+	Name   string
+	Decode func(body []byte) (interface{}, error)
+}
+
+// Decoders the response decoders by media type. Keys like +json match the structured
+// syntax suffixes of media types, and keys like text/* match the whole top level type.
+var Decoders = map[string]*Decoder{}
+
+// RegisterDecoder register the decoder of the media types, replacing the registered ones
+//
+//	pica.RegisterDecoder(&pica.Decoder{Name: "msgpack", Decode: decodeMsgpack}, "application/msgpack")
+func RegisterDecoder(decoder *Decoder, mediaTypes ...string) {
+	for _, mediaType := range mediaTypes {
+		Decoders[strings.ToLower(mediaType)] = decoder
+	}
+}
+
+func init() {
+	RegisterDecoder(&Decoder{Name: "json", Decode: decodeJson}, "application/json", "+json")
+	RegisterDecoder(&Decoder{Name: "xml", Decode: decodeXml}, "application/xml", "text/xml", "+xml")
+	RegisterDecoder(&Decoder{Name: "yaml", Decode: decodeYaml}, "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml", "+yaml")
+	RegisterDecoder(&Decoder{Name: "form", Decode: decodeForm}, "application/x-www-form-urlencoded")
+	RegisterDecoder(&Decoder{Name: "text", Decode: decodeText}, "text/*")
+}
+
+// FindDecoder the decoder of the content type, nil if none
+func FindDecoder(contentType string) *Decoder {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	if decoder, ok := Decoders[mediaType]; ok {
+		return decoder
+	}
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		if decoder, ok := Decoders[mediaType[i:]]; ok {
+			return decoder
+		}
+	}
+	if i := strings.Index(mediaType, "/"); i >= 0 {
+		if decoder, ok := Decoders[mediaType[:i]+"/*"]; ok {
+			return decoder
+		}
+	}
+	return nil
+}
+
+// decodeResponse decode the body of res by its content type, objects at the root become vm maps
+func decodeResponse(res *ApiResponse) (*Decoder, interface{}, error) {
+	decoder := FindDecoder(res.Headers.Get("Content-Type"))
+	if decoder == nil {
+		return nil, nil, nil
+	}
+	doc, err := decoder.Decode(res.Body)
+	if err != nil {
+		return decoder, nil, err
+	}
+	if obj, ok := doc.(map[string]interface{}); ok {
+		values := make(map[string]funny.Value, len(obj))
+		for k, v := range obj {
+			values[k] = funny.Value(v)
+		}
+		doc = values
+	}
+	return decoder, doc, nil
+}
+
+func decodeJson(body []byte) (interface{}, error) {
+	var doc interface{}
+	err := json.Unmarshal(body, &doc)
+	return doc, err
+}
+
+func decodeYaml(body []byte) (interface{}, error) {
+	var doc interface{}
+	err := yaml.Unmarshal(body, &doc)
+	return doc, err
+}
+
+// decodeForm single values become strings, repeated ones lists
+func decodeForm(body []byte) (interface{}, error) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	doc := make(map[string]interface{}, len(values))
+	for key, vals := range values {
+		if len(vals) == 1 {
+			doc[key] = vals[0]
+			continue
+		}
+		list := make([]interface{}, len(vals))
+		for i, val := range vals {
+			list[i] = val
+		}
+		doc[key] = list
+	}
+	return doc, nil
+}
+
+func decodeText(body []byte) (interface{}, error) {
+	return string(body), nil
+}
+
+// decodeXml the navigable map of a xml document like
+//
+//	<user id="1"><name>pica</name><tag>a</tag><tag>b</tag></user>
+//
+// becomes {user = {'@id' = '1', name = 'pica', tag = ['a', 'b']}}. Attributes are
+// prefixed by @, the text of elements having attributes or children is #text.
+func decodeXml(body []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			elem, err := decodeXmlElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: elem}, nil
+		}
+	}
+}
+
+func decodeXmlElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	elem := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		elem["@"+attr.Name.Local] = attr.Value
+	}
+	text := new(strings.Builder)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXmlElement(decoder, token)
+			if err != nil {
+				return nil, err
+			}
+			name := token.Name.Local
+			switch exists := elem[name].(type) {
+			case nil:
+				elem[name] = child
+			case []interface{}:
+				elem[name] = append(exists, child)
+			default:
+				elem[name] = []interface{}{exists, child}
+			}
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(elem) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				elem["#text"] = content
+			}
+			return elem, nil
+		}
+	}
+}
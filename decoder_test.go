@@ -0,0 +1,172 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDecoder(t *testing.T) {
+	assert.Equal(t, "json", FindDecoder("application/json; charset=utf-8").Name)
+	assert.Equal(t, "json", FindDecoder("application/problem+json").Name)
+	assert.Equal(t, "xml", FindDecoder("application/atom+xml").Name)
+	assert.Equal(t, "xml", FindDecoder("text/xml").Name)
+	assert.Equal(t, "yaml", FindDecoder("application/x-yaml").Name)
+	assert.Equal(t, "form", FindDecoder("application/x-www-form-urlencoded").Name)
+	assert.Equal(t, "text", FindDecoder("text/html").Name)
+	assert.Nil(t, FindDecoder("application/octet-stream"))
+	assert.Nil(t, FindDecoder(""))
+}
+
+func TestDecodeXml(t *testing.T) {
+	doc, err := decodeXml([]byte(`<?xml version="1.0"?>
+<user id="1"><name>pica</name><tag>a</tag><tag>b</tag><note lang="en">hi</note></user>`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"user": map[string]interface{}{
+			"@id":  "1",
+			"name": "pica",
+			"tag":  []interface{}{"a", "b"},
+			"note": map[string]interface{}{"@lang": "en", "#text": "hi"},
+		},
+	}, doc)
+}
+
+func TestAPIRunner_Decoders(t *testing.T) {
+	RegisterDecoder(&Decoder{Name: "csv", Decode: func(body []byte) (interface{}, error) {
+		var rows []interface{}
+		for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+			rows = append(rows, line)
+		}
+		return rows, nil
+	}}, "text/csv")
+	defer delete(Decoders, "text/csv")
+
+	bodies := map[string][2]string{
+		"/xml":  {"application/xml", `<users><user id="1"><name>a</name></user><user id="2"><name>b</name></user></users>`},
+		"/yaml": {"application/yaml", "name: pica\ntags:\n  - a\n  - b\n"},
+		"/form": {"application/x-www-form-urlencoded", "name=pica&tag=a&tag=b"},
+		"/text": {"text/plain; charset=utf-8", "pong"},
+		"/csv":  {"text/csv", "a,1\nb,2\n"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := bodies[r.URL.Path]
+		w.Header().Set("Content-Type", body[0])
+		w.Write([]byte(body[1]))
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /xml getXml
+query = {}
+// Response
+expectEqual(jsonpath('$.users.user[1].name', xml), 'b')
+expectEqual(xpath('//user[@id="1"]/name'), ['a'])
+expectEqual(xpath('count(//user)'), 2)
+
+// GET /yaml getYaml
+query = {}
+// Response
+expectEqual(yaml.name, 'pica')
+expectLen(yaml.tags, 2)
+
+// GET /form getForm
+query = {}
+// Response
+expectEqual(form.name, 'pica')
+expectEqual(form.tag, ['a', 'b'])
+
+// GET /text getText
+query = {}
+// Response
+expectEqual(text, 'pong')
+
+// GET /csv getCsv
+query = {}
+// Response
+expectEqual(csv, ['a,1', 'b,2'])
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(runner.Results))
+	for _, result := range runner.Results {
+		assert.True(t, result.Passed, result.Error)
+	}
+}
+
+func TestAPIRunner_DecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/broken" {
+			w.Write([]byte(`{"name":`))
+			return
+		}
+		w.Write([]byte(`{"name":"pica"}`))
+	}))
+	defer server.Close()
+
+	out := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /user getUser
+query = {}
+// Response
+expectEqual(json.name, 'pica')
+
+// GET /broken getBroken
+query = {}
+// Response
+expectEqual(json, nil)
+`))
+	runner.Reporter = NewOutput(false, out)
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, "", runner.Results[0].DecodeError)
+	// the json of the last api is not left bound
+	assert.True(t, runner.Results[1].Passed, runner.Results[1].Error)
+	assert.Equal(t, "decode json: unexpected end of JSON input", runner.Results[1].DecodeError)
+	assert.Contains(t, out.String(), "decode json: unexpected end of JSON input")
+}
+
+func TestAPIRunner_DecodeReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/b" {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(500)
+			w.Write([]byte("boom"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":"yes"}`))
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /a a
+query = {}
+// Response
+assert(json.ok == 'yes')
+
+// GET /b b
+query = {}
+// Response
+expectEqual(text, 'boom')
+expectEqual(json.ok, 'yes')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.NotNil(t, runner.Run())
+	assert.True(t, runner.Results[0].Passed, runner.Results[0].Error)
+	// the json of a is not bound for b
+	assert.False(t, runner.Results[1].Passed)
+	assert.True(t, runner.Results[1].Assertions[0].Passed)
+	assert.False(t, runner.Results[1].Assertions[1].Passed)
+}
@@ -0,0 +1,62 @@
+package pica
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plan get the selected api items in running order, the apis named by
+// `// depends: createUser` annotations run before the ones depending on them,
+// even when they are not selected themselves.
+func (runner *APIRunner) Plan() ([]*ApiItem, error) {
+	byName := make(map[string]*ApiItem)
+	for _, item := range runner.APIItems {
+		if item.Request.Name != "" {
+			byName[item.Request.Name] = item
+		}
+	}
+
+	var plan []*ApiItem
+	done := make(map[*ApiItem]bool)
+	var visiting []*ApiItem
+	var visit func(item *ApiItem) error
+	visit = func(item *ApiItem) error {
+		if done[item] {
+			return nil
+		}
+		for index, v := range visiting {
+			if v == item {
+				var names []string
+				for _, v := range visiting[index:] {
+					names = append(names, v.Request.Name)
+				}
+				names = append(names, item.Request.Name)
+				return fmt.Errorf("dependency cycle %s", strings.Join(names, " -> "))
+			}
+		}
+		visiting = append(visiting, item)
+		for _, name := range item.Request.Depends {
+			dep, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("api [%s] depends on unknown api [%s]", item.Request.Name, name)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visiting = visiting[:len(visiting)-1]
+		done[item] = true
+		plan = append(plan, item)
+		return nil
+	}
+
+	for _, item := range runner.APIItems {
+		if !runner.selected(item) {
+			continue
+		}
+		if err := visit(item); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,78 @@
+package pica
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dependsTestFile = `
+// GET /api/users/<id> getUser
+// depends: createUser
+query = {}
+
+// DELETE /api/users/<id> deleteUser
+// depends: getUser
+
+// POST /api/users createUser
+post = {}
+// Response
+assert(status == 200)
+id = json.id
+`
+
+func TestAPIRunner_Plan(t *testing.T) {
+	runner := NewAPIRunnerFromContent([]byte(dependsTestFile))
+	runner.Parse()
+	runner.ParseAPIItems()
+
+	names := func(items []*ApiItem) (results []string) {
+		for _, item := range items {
+			results = append(results, item.Request.Name)
+		}
+		return
+	}
+
+	plan, err := runner.Plan()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"createUser", "getUser", "deleteUser"}, names(plan))
+
+	runner.APINames = []string{"deleteUser"}
+	plan, err = runner.Plan()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"createUser", "getUser", "deleteUser"}, names(plan))
+
+	runner.APIItems[2].Request.Depends = []string{"deleteUser"}
+	_, err = runner.Plan()
+	assert.EqualError(t, err, "dependency cycle deleteUser -> getUser -> createUser -> deleteUser")
+
+	runner.APIItems[2].Request.Depends = []string{"updateUser"}
+	_, err = runner.Plan()
+	assert.EqualError(t, err, "api [createUser] depends on unknown api [updateUser]")
+}
+
+func TestAPIRunner_RunDepends(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "10"}`))
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + dependsTestFile))
+	runner.APINames = []string{"getUser"}
+	err := runner.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"POST /api/users", "GET /api/users/10"}, paths)
+
+	// without its dependency the url of getUser misses the id
+	runner = NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + dependsTestFile))
+	runner.Parse()
+	runner.ParseAPIItems()
+	runner.RunInitLines()
+	err = runner.RunSingle(runner.APIItems[0])
+	assert.Contains(t, err.Error(), "variables [id] of url")
+}
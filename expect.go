@@ -0,0 +1,273 @@
+package pica
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jerloo/funny"
+)
+
+// Assertion the outcome of one expect builtin
+type Assertion struct {
+	Name     string
+	Passed   bool
+	Expected string
+	Actual   string
+	Position string
+}
+
+// Message a readable message like
+//
+//	expectEqual failed at pica.fun:12
+//	  expected: 200
+//	  actual:   404
+func (a *Assertion) Message() string {
+	status := "passed"
+	if !a.Passed {
+		status = "failed"
+	}
+	return fmt.Sprintf("%s %s at %s\n  expected: %s\n  actual:   %s", a.Name, status, a.Position, a.Expected, a.Actual)
+}
+
+func init() {
+	for name := range expectFunctions {
+		ResponseFunctions[name] = true
+	}
+}
+
+// expectFunctions check the args, returning whether passed and the descriptions of expected and actual
+var expectFunctions = map[string]func(runner *APIRunner, args []funny.Value) (bool, string, string){
+	// expectEqual(actual, expected)
+	"expectEqual": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectEqual", args, 2)
+		return valuesEqual(args[0], args[1]), describe(args[1]), describe(args[0])
+	},
+	// expectContains(container, item) for substrings, list items and map keys
+	"expectContains": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectContains", args, 2)
+		expected := fmt.Sprintf("contains %s", describe(args[1]))
+		switch container := normalize(args[0]).(type) {
+		case string:
+			s, ok := args[1].(string)
+			return ok && strings.Contains(container, s), expected, describe(container)
+		case []interface{}:
+			for _, item := range container {
+				if valuesEqual(item, args[1]) {
+					return true, expected, describe(container)
+				}
+			}
+		case map[string]interface{}:
+			key, ok := args[1].(string)
+			if ok {
+				_, ok = container[key]
+			}
+			return ok, expected, describe(container)
+		}
+		return false, expected, describe(args[0])
+	},
+	// expectMatch(value, regex)
+	"expectMatch": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectMatch", args, 2)
+		pattern, ok := args[1].(string)
+		if !ok {
+			panic("expectMatch regex must be a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, "match /" + pattern + "/", "invalid pattern, " + err.Error()
+		}
+		s, ok := args[0].(string)
+		return ok && re.MatchString(s), "match /" + pattern + "/", describe(args[0])
+	},
+	// expectType(value, 'string'), types are string number int float bool list map nil
+	"expectType": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectType", args, 2)
+		expected, ok := args[1].(string)
+		if !ok {
+			panic("expectType type must be a string")
+		}
+		actual := typeName(args[0])
+		passed := actual == expected ||
+			expected == "number" && (actual == "int" || actual == "float") ||
+			expected == "int" && actual == "float" && args[0].(float64) == float64(int64(args[0].(float64)))
+		return passed, "type " + expected, "type " + actual
+	},
+	// expectLen(value, n) for strings, lists and maps
+	"expectLen": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectLen", args, 2)
+		expected, err := intValue(args[1])
+		if err != nil {
+			panic(err)
+		}
+		actual := -1
+		switch v := normalize(args[0]).(type) {
+		case string:
+			actual = len(v)
+		case []interface{}:
+			actual = len(v)
+		case map[string]interface{}:
+			actual = len(v)
+		}
+		return actual == expected, fmt.Sprintf("len %d", expected), fmt.Sprintf("len %d", actual)
+	},
+	// expectHeader(name, [value]) checks the response header is present, or equals value
+	"expectHeader": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		if len(args) == 0 {
+			panic("expectHeader(name, [value]) requires the header name")
+		}
+		name := fmt.Sprint(args[0])
+		values := runner.item.Response.Headers.Values(name)
+		actual := fmt.Sprintf("%s: %s", name, strings.Join(values, ", "))
+		if len(args) == 1 {
+			return len(values) > 0, fmt.Sprintf("header %s", name), actual
+		}
+		expected := fmt.Sprintf("%s: %v", name, args[1])
+		return runner.item.Response.Headers.Get(name) == fmt.Sprint(args[1]), expected, actual
+	},
+	// expectStatusIn([200, 201])
+	"expectStatusIn": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectStatusIn", args, 1)
+		status := runner.item.Response.Status
+		codes, ok := args[0].([]interface{})
+		if !ok {
+			panic("expectStatusIn requires a list of status codes")
+		}
+		for _, code := range codes {
+			if valuesEqual(code, status) {
+				return true, describe(codes), describe(status)
+			}
+		}
+		return false, describe(codes), describe(status)
+	},
+	// expectLatency(ms) checks the duration of the last attempt of the request
+	"expectLatency": func(runner *APIRunner, args []funny.Value) (bool, string, string) {
+		checkArgs("expectLatency", args, 1)
+		max, err := durationValue(args[0])
+		if err != nil {
+			panic(err)
+		}
+		var actual time.Duration
+		if attempts := runner.result.Attempts; len(attempts) > 0 {
+			actual = attempts[len(attempts)-1].Duration
+		}
+		return actual <= max, fmt.Sprintf("<= %s", max), actual.String()
+	},
+}
+
+// expect create the builtin function of an expect check, recording its assertion into the result
+func (runner *APIRunner) expect(name string) funny.BuiltinFunction {
+	check := expectFunctions[name]
+	return func(interpreter *funny.Funny, args []funny.Value) funny.Value {
+		if runner.item == nil || runner.result == nil {
+			panic(fmt.Sprintf("%s must be called in the response block of an api", name))
+		}
+		pos := interpreter.Current
+		if call := findCall(runner.statement, name); call != nil {
+			pos = call.Position
+		}
+		passed, expected, actual := check(runner, args)
+		assertion := &Assertion{
+			Name:     name,
+			Passed:   passed,
+			Expected: expected,
+			Actual:   actual,
+			Position: positionString(pos),
+		}
+		runner.result.Assertions = append(runner.result.Assertions, assertion)
+		runner.Reporter.AssertionResult(runner.item, assertion)
+		return funny.Value(passed)
+	}
+}
+
+// checkArgs panics when the count of args is not count
+func checkArgs(name string, args []funny.Value, count int) {
+	if len(args) != count {
+		panic(fmt.Sprintf("%s requires %d arguments but got %d", name, count, len(args)))
+	}
+}
+
+// positionString file:line with lines counting from 1
+func positionString(pos funny.Position) string {
+	return fmt.Sprintf("%s:%d", pos.File, pos.Line+1)
+}
+
+// describe a value as json, strings stay quoted so 200 and '200' differ
+func describe(val interface{}) string {
+	data, err := json.Marshal(normalize(val))
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return string(data)
+}
+
+func typeName(val funny.Value) string {
+	switch normalize(val).(type) {
+	case nil:
+		return "nil"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int:
+		return "int"
+	case float64:
+		return "float"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	default:
+		return funny.Typing(val)
+	}
+}
+
+// normalize convert the maps of the vm to map[string]interface{} recursively
+func normalize(val funny.Value) interface{} {
+	switch val := val.(type) {
+	case map[string]funny.Value:
+		result := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			result[k] = normalize(v)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			result[k] = normalize(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(val))
+		for index, v := range val {
+			result[index] = normalize(v)
+		}
+		return result
+	default:
+		return val
+	}
+}
+
+// valuesEqual deep equal, treating ints of the vm and float64 of json the same
+func valuesEqual(a, b funny.Value) bool {
+	return reflect.DeepEqual(numbersToFloat(normalize(a)), numbersToFloat(normalize(b)))
+}
+
+func numbersToFloat(val interface{}) interface{} {
+	switch val := val.(type) {
+	case int:
+		return float64(val)
+	case map[string]interface{}:
+		for k, v := range val {
+			val[k] = numbersToFloat(v)
+		}
+	case []interface{}:
+		for index, v := range val {
+			val[index] = numbersToFloat(v)
+		}
+	}
+	return val
+}
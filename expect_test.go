@@ -0,0 +1,72 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Expect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id": 10, "name": "pica", "tags": ["a", "b"], "profile": {"age": 18}}`))
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// POST /api/users createUser
+post = {}
+// Response
+expectStatusIn([200, 201])
+expectEqual(json.id, 10)
+expectEqual(json.profile, {age = 18})
+expectContains(json.tags, 'b')
+expectContains(json.name, 'ic')
+expectMatch(json.name, '^p.*a$')
+expectType(json.id, 'int')
+expectType(json.tags, 'list')
+expectLen(json.tags, 2)
+expectHeader('X-Request-Id', 'abc')
+expectLatency(5000)
+
+// GET /api/users getUser
+query = {}
+// Response
+expectEqual(json.name, 'test')
+expectLen(json.tags, 3)
+expectEqual(status, 201)
+expectMatch(json.name, '(')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.NotNil(t, err)
+
+	first := runner.Results[0]
+	assert.True(t, first.Passed, first.Error)
+	assert.Equal(t, 11, len(first.Assertions))
+
+	second := runner.Results[1]
+	assert.False(t, second.Passed)
+	assert.Equal(t, 4, len(second.Assertions))
+	assert.Equal(t, &Assertion{
+		Name:     "expectEqual",
+		Passed:   false,
+		Expected: `"test"`,
+		Actual:   `"pica"`,
+		Position: ":22",
+	}, second.Assertions[0])
+	assert.Equal(t, "len 3", second.Assertions[1].Expected)
+	assert.Equal(t, "len 2", second.Assertions[1].Actual)
+	// invalid patterns fail the assertion
+	assert.False(t, second.Assertions[3].Passed)
+	assert.Equal(t, "match /(/", second.Assertions[3].Expected)
+	assert.Equal(t, "invalid pattern, error parsing regexp: missing closing ): `(`", second.Assertions[3].Actual)
+	assert.Contains(t, second.Error, "3 expectations failed")
+}
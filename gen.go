@@ -1,9 +1,13 @@
 package pica
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime"
+	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
 	"unicode"
@@ -24,6 +28,8 @@ func NewScriptsGenerator(name string) ScriptsGenerator {
 	return map[string]ScriptsGenerator{
 		"postman":  &PostmanScriptsGenerator{},
 		"swagger2": &Swagger2ScriptsGenerator{},
+		"har":      &HARScriptsGenerator{},
+		"curl":     &CurlScriptsGenerator{},
 	}[name]
 }
 
@@ -185,3 +191,313 @@ func genItems(nameRoot string, item []*postman.Items) (results []*postman.Items)
 	}
 	return results
 }
+
+// scriptAPI one api block of a generated pica file
+type scriptAPI struct {
+	Method string
+	// Path relative to the base url, with placeholders like /users/<id> and the query
+	Path    string
+	Name    string
+	BaseUrl string
+	// Vars the values of the placeholders, funny expressions assigned in the request lines
+	Vars    []scriptVar
+	Headers map[string]string
+	// Auth a funny literal of the auth block, none when empty, or a comment when it has no funny literal
+	Auth string
+	// Body a funny expression assigned to body, or a comment when it has no funny literal
+	Body string
+	// notes comments on the values left out of the api, set by writeScripts
+	notes []string
+	// Status the status asserted in the response block, none when 0
+	Status int
+}
+
+type scriptVar struct {
+	Name  string
+	Value string
+}
+
+// writeScripts a pica file of the apis. The most used base url and the headers shared by all apis
+// are set in the init lines, and every api changes baseUrl, headers and auth left by the apis before it.
+func writeScripts(apis []*scriptAPI) string {
+	for _, api := range apis {
+		api.Headers = quotableHeaders(api)
+	}
+	baseUrl := mostUsedBaseUrl(apis)
+	common := commonHeaders(apis)
+	builder := &strings.Builder{}
+	writeBaseUrl(builder, baseUrl)
+	hasHeaders := false
+	for _, api := range apis {
+		hasHeaders = hasHeaders || len(api.Headers) > 0
+	}
+	if hasHeaders {
+		fmt.Fprintf(builder, "headers = %s\n", funnyHeaders(common))
+	}
+
+	names := map[string]bool{}
+	currentBaseUrl, current, currentAuth := baseUrl, common, ""
+	for index, api := range apis {
+		if names[api.Name] {
+			api.Name = fmt.Sprintf("%s%d", api.Name, index)
+		}
+		names[api.Name] = true
+		fmt.Fprintf(builder, "\n// %s %s %s\n", api.Method, api.Path, api.Name)
+		if api.BaseUrl != currentBaseUrl {
+			writeBaseUrl(builder, api.BaseUrl)
+			currentBaseUrl = api.BaseUrl
+		}
+		for _, v := range api.Vars {
+			fmt.Fprintf(builder, "%s = %s\n", v.Name, v.Value)
+		}
+		for _, note := range api.notes {
+			fmt.Fprintln(builder, note)
+		}
+		if hasHeaders {
+			writeHeaders(builder, current, api.Headers)
+			current = api.Headers
+		}
+		if api.Auth != currentAuth {
+			if api.Auth == "" {
+				fmt.Fprintln(builder, "auth = {}")
+			} else if strings.HasPrefix(api.Auth, "//") {
+				fmt.Fprintln(builder, api.Auth)
+			} else {
+				fmt.Fprintf(builder, "auth = %s\n", api.Auth)
+			}
+			currentAuth = api.Auth
+		}
+		if api.Body != "" {
+			if strings.HasPrefix(api.Body, "//") {
+				fmt.Fprintln(builder, api.Body)
+			} else {
+				fmt.Fprintf(builder, "body = %s\n", api.Body)
+			}
+		}
+		if api.Status != 0 {
+			fmt.Fprintf(builder, "// Response\nassert(status == %d)\n", api.Status)
+		}
+	}
+	return builder.String()
+}
+
+// quotableHeaders the headers of api funny has strings for, the others are noted as left out
+func quotableHeaders(api *scriptAPI) map[string]string {
+	headers := map[string]string{}
+	for _, name := range sortedKeys(api.Headers) {
+		_, nameOk := funnyString(name)
+		_, valueOk := funnyString(api.Headers[name])
+		if nameOk && valueOk {
+			headers[name] = api.Headers[name]
+		} else {
+			api.notes = append(api.notes, leftOut("the header "+name))
+		}
+	}
+	return headers
+}
+
+func writeBaseUrl(builder *strings.Builder, baseUrl string) {
+	if quoted, ok := funnyString(baseUrl); ok {
+		fmt.Fprintf(builder, "baseUrl = %s\n", quoted)
+	} else {
+		fmt.Fprintln(builder, leftOut("the base url "+baseUrl))
+	}
+}
+
+// writeHeaders the changes from the current headers to the wanted ones, the whole map
+// when a header is removed
+func writeHeaders(builder *strings.Builder, current, wanted map[string]string) {
+	for name := range current {
+		if _, ok := wanted[name]; !ok {
+			fmt.Fprintf(builder, "headers = %s\n", funnyHeaders(wanted))
+			return
+		}
+	}
+	for _, name := range sortedKeys(wanted) {
+		if value, ok := current[name]; !ok || value != wanted[name] {
+			quotedName, _ := funnyString(name)
+			quotedValue, _ := funnyString(wanted[name])
+			fmt.Fprintf(builder, "headers[%s] = %s\n", quotedName, quotedValue)
+		}
+	}
+}
+
+func mostUsedBaseUrl(apis []*scriptAPI) string {
+	counts := map[string]int{}
+	result := ""
+	for _, api := range apis {
+		counts[api.BaseUrl]++
+		if counts[api.BaseUrl] > counts[result] {
+			result = api.BaseUrl
+		}
+	}
+	return result
+}
+
+// commonHeaders the headers of the same value in all apis
+func commonHeaders(apis []*scriptAPI) map[string]string {
+	common := map[string]string{}
+	if len(apis) == 0 {
+		return common
+	}
+	for name, value := range apis[0].Headers {
+		common[name] = value
+	}
+	for _, api := range apis[1:] {
+		for name, value := range common {
+			if api.Headers[name] != value {
+				delete(common, name)
+			}
+		}
+	}
+	return common
+}
+
+func funnyHeaders(headers map[string]string) string {
+	if len(headers) == 0 {
+		return "{}"
+	}
+	builder := &strings.Builder{}
+	builder.WriteString("{\n")
+	for _, name := range sortedKeys(headers) {
+		quotedName, _ := funnyString(name)
+		value, _ := funnyString(headers[name])
+		fmt.Fprintf(builder, "    %s = %s\n", quotedName, value)
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// funnyString s as a funny string, false when s has quotes or line breaks, funny strings have no escapes
+func funnyString(s string) (string, bool) {
+	return funnyLiteral(s, "")
+}
+
+// leftOut the comment on a value left out of a generated api
+func leftOut(what string) string {
+	return fmt.Sprintf("// %s is left out, funny strings have no quotes or line breaks", what)
+}
+
+// funnyLiteral the funny literal of a decoded json value, false for values funny has no
+// literal for, like floats or strings with quotes or line breaks
+func funnyLiteral(val interface{}, indent string) (string, bool) {
+	switch val := val.(type) {
+	case nil:
+		return "nil", true
+	case bool:
+		return fmt.Sprint(val), true
+	case json.Number:
+		n, err := val.Int64()
+		if err != nil {
+			return "", false
+		}
+		if n < 0 {
+			// funny has no negative literals
+			return fmt.Sprintf("0 - %d", -n), true
+		}
+		return fmt.Sprint(n), true
+	case string:
+		if strings.ContainsAny(val, "'\r\n") {
+			return "", false
+		}
+		return "'" + val + "'", true
+	case []interface{}:
+		items := make([]string, 0, len(val))
+		for _, item := range val {
+			literal, ok := funnyLiteral(item, indent)
+			if !ok {
+				return "", false
+			}
+			items = append(items, literal)
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return "{}", true
+		}
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		builder := &strings.Builder{}
+		builder.WriteString("{\n")
+		for _, key := range keys {
+			name, ok := funnyLiteral(key, "")
+			if !ok {
+				return "", false
+			}
+			literal, ok := funnyLiteral(val[key], indent+"    ")
+			if !ok {
+				return "", false
+			}
+			fmt.Fprintf(builder, "%s    %s = %s\n", indent, name, literal)
+		}
+		builder.WriteString(indent + "}")
+		return builder.String(), true
+	default:
+		return "", false
+	}
+}
+
+// funnyMultipart the map of a multipart body, files are `@path` fields
+func funnyMultipart(form map[string]interface{}) string {
+	if literal, ok := funnyLiteral(form, ""); ok {
+		return literal
+	}
+	return leftOut("the multipart body")
+}
+
+// funnyBody the funny expression of a request body by its content type. Json bodies are
+// literals or compact json strings, form bodies are maps and others are strings.
+func funnyBody(contentType string, text string) string {
+	if text == "" {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		var val interface{}
+		if decoder.Decode(&val) == nil {
+			if literal, ok := funnyLiteral(val, ""); ok {
+				return literal
+			}
+		}
+		// quotes of json strings are escaped for the json decoder of the server
+		compact := new(bytes.Buffer)
+		if json.Compact(compact, []byte(text)) == nil {
+			return "'" + strings.ReplaceAll(compact.String(), "'", `\u0027`) + "'"
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(text)
+		if err == nil {
+			// repeated fields have no map
+			form := map[string]interface{}{}
+			for name, value := range values {
+				if len(value) > 1 {
+					form = nil
+					break
+				}
+				form[name] = value[0]
+			}
+			if literal, ok := funnyLiteral(form, ""); ok && form != nil {
+				return literal
+			}
+		}
+	}
+	if quoted, ok := funnyString(text); ok {
+		return quoted
+	}
+	return leftOut("the " + mediaType + " body")
+}
@@ -0,0 +1,478 @@
+package pica
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// curlValueOptions options of curl taking a value, by their short and long names
+var curlValueOptions = map[string]string{
+	"-X": "--request",
+	"-H": "--header",
+	"-d": "--data",
+	"-F": "--form",
+	"-u": "--user",
+	"-A": "--user-agent",
+	"-e": "--referer",
+	"-b": "--cookie",
+	"-c": "--cookie-jar",
+	"-o": "--output",
+	"-m": "--max-time",
+	"-x": "--proxy",
+	"-T": "--upload-file",
+	"-w": "--write-out",
+	"-E": "--cert",
+	"-K": "--config",
+	"-r": "--range",
+	"-U": "--proxy-user",
+	"-D": "--dump-header",
+	"-C": "--continue-at",
+	"-Q": "--quote",
+	"-t": "--telnet-option",
+	"-y": "--speed-time",
+	"-Y": "--speed-limit",
+	"-z": "--time-cond",
+}
+
+var curlLongValueOptions = map[string]bool{
+	"--data-raw": true, "--data-binary": true, "--data-ascii": true, "--data-urlencode": true,
+	"--form-string": true, "--json": true, "--url": true, "--connect-timeout": true,
+	"--cacert": true, "--capath": true, "--key": true, "--key-type": true, "--cert-type": true,
+	"--pass": true, "--ciphers": true, "--tls-max": true, "--retry": true, "--retry-delay": true,
+	"--retry-max-time": true, "--max-redirs": true, "--oauth2-bearer": true, "--resolve": true,
+	"--connect-to": true, "--limit-rate": true, "--interface": true, "--dns-servers": true,
+	"--unix-socket": true, "--aws-sigv4": true, "--noproxy": true, "--trace": true,
+	"--trace-ascii": true, "--stderr": true, "--local-port": true, "--max-filesize": true,
+	"--expect100-timeout": true, "--keepalive-time": true, "--variable": true, "--request-target": true,
+}
+
+func init() {
+	for _, long := range curlValueOptions {
+		curlLongValueOptions[long] = true
+	}
+}
+
+// CurlScriptsGenerator turns curl command lines, like the snippets of wikis and chats, into apis.
+// Commands are separated by new lines, ; or &&, and continued by a backslash at the end of a line.
+// The filename - reads the commands from stdin.
+type CurlScriptsGenerator struct {
+}
+
+func (generator *CurlScriptsGenerator) Name() string {
+	return "curl"
+}
+
+func (generator *CurlScriptsGenerator) Generate(filename string) string {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		panic(err)
+	}
+	commands, err := shellCommands(string(data))
+	if err != nil {
+		panic(fmt.Errorf("curl commands of %s: %s", filename, err.Error()))
+	}
+	var apis []*scriptAPI
+	for _, args := range commands {
+		if len(args) > 0 && args[0] == "$" {
+			args = args[1:]
+		}
+		if len(args) == 0 || args[0] != "curl" {
+			continue
+		}
+		api, err := curlScriptAPI(args[1:])
+		if err != nil {
+			panic(fmt.Errorf("curl %s: %s", strings.Join(args[1:], " "), err.Error()))
+		}
+		apis = append(apis, api)
+	}
+	return writeScripts(apis)
+}
+
+// curlCommand the options of one curl command line that make the request
+type curlCommand struct {
+	method     string
+	url        string
+	headers    http.Header
+	data       []string
+	dataFile   string
+	json       []string
+	form       map[string]interface{}
+	user       string
+	bearer     string
+	get        bool
+	head       bool
+	uploadFile string
+}
+
+// parseCurl the curl options of args, like -XPOST, -sSL or --header 'Accept: */*'.
+// Options unrelated to the request are skipped.
+func parseCurl(args []string) (*curlCommand, error) {
+	command := &curlCommand{headers: http.Header{}}
+	var urls []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-" {
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			urls = append(urls, arg)
+			continue
+		}
+		name, value, hasValue := arg, "", false
+		if !strings.HasPrefix(arg, "--") {
+			// short options are grouped like -sSL, and the last one may take the rest as its value
+			name = ""
+			for j := 1; j < len(arg); j++ {
+				short := "-" + arg[j:j+1]
+				if long, ok := curlValueOptions[short]; ok {
+					name = long
+					if j+1 < len(arg) {
+						value, hasValue = arg[j+1:], true
+					}
+					break
+				}
+				switch short {
+				case "-G":
+					command.get = true
+				case "-I":
+					command.head = true
+				}
+			}
+			if name == "" {
+				continue
+			}
+		}
+		if !curlLongValueOptions[name] {
+			switch name {
+			case "--get":
+				command.get = true
+			case "--head":
+				command.head = true
+			}
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("option %s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if err := command.set(name, value); err != nil {
+			return nil, err
+		}
+		if name == "--url" {
+			urls = append(urls, value)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no url")
+	}
+	command.url = urls[0]
+	return command, nil
+}
+
+func (command *curlCommand) set(name, value string) error {
+	switch name {
+	case "--request":
+		command.method = strings.ToUpper(value)
+	case "--header":
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) == 2 {
+			command.headers.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		}
+	case "--user-agent":
+		command.headers.Set("User-Agent", value)
+	case "--referer":
+		command.headers.Set("Referer", value)
+	case "--cookie":
+		// cookie files are left out
+		if strings.Contains(value, "=") {
+			command.headers.Add("Cookie", value)
+		}
+	case "--data", "--data-ascii", "--data-binary":
+		if strings.HasPrefix(value, "@") {
+			command.dataFile = value[1:]
+			return nil
+		}
+		command.data = append(command.data, value)
+	case "--data-raw":
+		command.data = append(command.data, value)
+	case "--data-urlencode":
+		command.data = append(command.data, curlURLEncode(value))
+	case "--json":
+		if strings.HasPrefix(value, "@") {
+			command.dataFile = value[1:]
+		} else {
+			command.json = append(command.json, value)
+		}
+		command.headers.Set("Content-Type", "application/json")
+		if command.headers.Get("Accept") == "" {
+			command.headers.Set("Accept", "application/json")
+		}
+	case "--form", "--form-string":
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("form %s is not like name=value", value)
+		}
+		if command.form == nil {
+			command.form = map[string]interface{}{}
+		}
+		field := parts[1]
+		if name == "--form" && strings.HasPrefix(field, "@") {
+			// uploads like @avatar.png;type=image/png are @path for the runner
+			field = strings.SplitN(field, ";", 2)[0]
+		}
+		command.form[parts[0]] = field
+	case "--user":
+		command.user = value
+	case "--oauth2-bearer":
+		command.bearer = value
+	case "--upload-file":
+		command.uploadFile = value
+	}
+	return nil
+}
+
+// curlURLEncode one --data-urlencode value, encoding the content of content, =content or name=content
+func curlURLEncode(value string) string {
+	index := strings.Index(value, "=")
+	if index < 0 {
+		return url.QueryEscape(value)
+	}
+	if index == 0 {
+		return url.QueryEscape(value[1:])
+	}
+	return value[:index] + "=" + url.QueryEscape(value[index+1:])
+}
+
+// curlScriptAPI the api of the args of one curl command
+func curlScriptAPI(args []string) (*scriptAPI, error) {
+	command, err := parseCurl(args)
+	if err != nil {
+		return nil, err
+	}
+	rawUrl := command.url
+	if !strings.Contains(rawUrl, "://") {
+		rawUrl = "http://" + rawUrl
+	}
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	data := strings.Join(command.data, "&")
+	if command.get && data != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += data
+		data = ""
+	}
+
+	api := &scriptAPI{
+		BaseUrl: u.Scheme + "://" + u.Host,
+		Path:    u.EscapedPath(),
+		Headers: map[string]string{},
+	}
+	if api.Path == "" {
+		api.Path = "/"
+	}
+
+	hasBody := data != "" || len(command.json) > 0 || command.form != nil || command.dataFile != ""
+	switch {
+	case command.method != "":
+		api.Method = command.method
+	case command.head:
+		api.Method = http.MethodHead
+	case command.uploadFile != "":
+		api.Method = http.MethodPut
+	case hasBody && !command.get:
+		api.Method = http.MethodPost
+	default:
+		api.Method = http.MethodGet
+	}
+	api.Name = apiName(api.Method, strings.Split(u.Path, "/"), false)
+	if u.RawQuery != "" {
+		api.Path += "?" + u.RawQuery
+	}
+
+	contentType := command.headers.Get("Content-Type")
+	switch {
+	case command.form != nil:
+		// the boundary is made again by the runner
+		contentType = "multipart/form-data"
+		api.Body = funnyMultipart(command.form)
+	case len(command.json) > 0:
+		api.Body = funnyBody(contentType, strings.Join(command.json, ""))
+	case command.dataFile != "" || command.uploadFile != "":
+		file := command.dataFile
+		if file == "" {
+			file = command.uploadFile
+		}
+		if quoted, ok := funnyString(file); ok {
+			api.Body = fmt.Sprintf("file(%s)", quoted)
+		} else {
+			api.Body = leftOut("the body of file " + file)
+		}
+		if contentType == "" && command.uploadFile == "" {
+			contentType = "application/x-www-form-urlencoded"
+		}
+	case data != "":
+		if contentType == "" {
+			contentType = "application/x-www-form-urlencoded"
+		}
+		api.Body = funnyBody(contentType, data)
+	}
+	if contentType != "" {
+		command.headers.Set("Content-Type", contentType)
+	}
+	for name, values := range command.headers {
+		api.Headers[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+	}
+
+	switch {
+	case command.user != "":
+		parts := strings.SplitN(command.user, ":", 2)
+		auth := map[string]interface{}{"type": "basic", "username": parts[0], "password": ""}
+		if len(parts) == 2 {
+			auth["password"] = parts[1]
+		}
+		api.Auth = funnyAuth(auth)
+	case command.bearer != "":
+		api.Auth = funnyAuth(map[string]interface{}{"type": "bearer", "token": command.bearer})
+	}
+	return api, nil
+}
+
+func funnyAuth(auth map[string]interface{}) string {
+	if literal, ok := funnyLiteral(auth, ""); ok {
+		return literal
+	}
+	return leftOut("the " + auth["type"].(string) + " auth")
+}
+
+// shellCommands split text into commands of words like a posix shell does, with single quotes,
+// double quotes, $'...' strings and backslash escapes. Comments and pipes to other commands are left out.
+func shellCommands(text string) ([][]string, error) {
+	var commands [][]string
+	var words []string
+	word := &strings.Builder{}
+	inWord := false
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endCommand := func() {
+		endWord()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+					i++
+				}
+				if runes[i] != '\n' {
+					word.WriteRune(runes[i])
+					inWord = true
+				}
+			}
+		case r == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(string(runes[i+1 : end]))
+			inWord = true
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			for i += 2; i < len(runes) && runes[i] != '\''; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						word.WriteRune('\n')
+					case 't':
+						word.WriteRune('\t')
+					case 'r':
+						word.WriteRune('\r')
+					default:
+						word.WriteRune(runes[i])
+					}
+					continue
+				}
+				word.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated $' quote")
+			}
+			inWord = true
+		case r == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				word.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case r == '#' && !inWord:
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == '\n' || r == ';':
+			endCommand()
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			i++
+			endCommand()
+		case r == '|':
+			// the rest of a pipeline like | jq . is not a request
+			endCommand()
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == ' ' || r == '\t' || r == '\r':
+			endWord()
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	endCommand()
+	return commands, nil
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,143 @@
+package pica
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurlScriptsGenerator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pica-curl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	avatar := filepath.Join(dir, "avatar.png")
+	assert.Nil(t, ioutil.WriteFile(avatar, []byte("png"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, hasAuth := r.BasicAuth()
+		ok := r.Header.Get("Accept") == "application/json"
+		switch r.URL.Path {
+		case "/api/users":
+			data, _ := ioutil.ReadAll(r.Body)
+			ok = ok && (r.Method == "GET" && r.URL.RawQuery == "page=2" ||
+				r.Method == "POST" && string(data) == `{"age":3,"name":"pica"}`)
+		case "/api/login":
+			ok = ok && r.Method == "POST" && r.PostFormValue("user") == "me" && r.PostFormValue("pass") == "s3cret"
+		case "/api/users/42":
+			ok = ok && r.Method == "DELETE" && user == "admin" && password == "secret"
+		case "/api/avatars":
+			_, _, err := r.FormFile("avatar")
+			ok = ok && err == nil && r.FormValue("name") == "me"
+		case "/api/search":
+			ok = ok && r.Method == "GET" && r.URL.Query().Get("q") == "hello world"
+		case "/api/health":
+			ok = ok && !hasAuth
+		case "/api/notes":
+			// values funny has no strings for are left out, not sent changed
+			ok = ok && r.Method == "POST" && r.Header.Get("X-Note") == "" && r.ContentLength == 0
+		}
+		if !ok {
+			w.WriteHeader(400)
+		}
+	}))
+	defer server.Close()
+
+	commands := strings.ReplaceAll(`# users
+$ curl -sS 'https://api.example.com/api/users?page=2' -H 'Accept: application/json'
+curl -X POST https://api.example.com/api/users \
+  -H 'Accept: application/json' \
+  --json '{"name": "pica", "age": 3}'
+curl https://api.example.com/api/login -d user=me -d 'pass=s3cret' -H 'Accept: application/json'
+curl -u admin:secret -XDELETE "https://api.example.com/api/users/42" -H "Accept: application/json"
+curl -F 'avatar=@`+avatar+`;type=image/png' -F name=me https://api.example.com/api/avatars -H 'Accept: application/json' | jq .
+curl --data-urlencode 'q=hello world' -G https://api.example.com/api/search -H $'Accept: application/json' && curl https://api.example.com/api/health -H 'Accept: application/json'
+curl --data-binary "@it's.json" https://api.example.com/api/notes -H 'Accept: application/json' -H "X-Note: it's"
+`, "https://api.example.com", server.URL)
+	filename := filepath.Join(dir, "commands.sh")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(commands), 0644))
+
+	generator := NewScriptsGenerator("curl")
+	assert.Equal(t, "curl", generator.Name())
+	script := generator.Generate(filename)
+	assert.Equal(t, strings.Join([]string{
+		"baseUrl = '" + server.URL + "'",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"",
+		"// GET /api/users?page=2 getApiUsers",
+		"",
+		"// POST /api/users postApiUsers",
+		"headers['Content-Type'] = 'application/json'",
+		"body = {",
+		"    'age' = 3",
+		"    'name' = 'pica'",
+		"}",
+		"",
+		"// POST /api/login postApiLogin",
+		"headers['Content-Type'] = 'application/x-www-form-urlencoded'",
+		"body = {",
+		"    'pass' = 's3cret'",
+		"    'user' = 'me'",
+		"}",
+		"",
+		"// DELETE /api/users/42 deleteApiUsers42",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"auth = {",
+		"    'password' = 'secret'",
+		"    'type' = 'basic'",
+		"    'username' = 'admin'",
+		"}",
+		"",
+		"// POST /api/avatars postApiAvatars",
+		"headers['Content-Type'] = 'multipart/form-data'",
+		"auth = {}",
+		"body = {",
+		"    'avatar' = '@" + avatar + "'",
+		"    'name' = 'me'",
+		"}",
+		"",
+		"// GET /api/search?q=hello+world getApiSearch",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"",
+		"// GET /api/health getApiHealth",
+		"",
+		"// POST /api/notes postApiNotes",
+		"// the header X-Note is left out, funny strings have no quotes or line breaks",
+		"headers['Content-Type'] = 'application/x-www-form-urlencoded'",
+		"// the body of file it's.json is left out, funny strings have no quotes or line breaks",
+		"",
+	}, "\n"), script)
+
+	runner := NewAPIRunnerFromContent([]byte(script))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, 8, len(runner.Results))
+	for _, result := range runner.Results {
+		assert.Equal(t, 200, result.Status, result.Name)
+	}
+}
+
+func TestShellCommands(t *testing.T) {
+	commands, err := shellCommands("curl -H \"X-A: \\\"a\\\"\" \\\n  'b c'$'\\td' # comment\ncurl x; echo y")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{
+		{"curl", "-H", `X-A: "a"`, "b c\td"},
+		{"curl", "x"},
+		{"echo", "y"},
+	}, commands)
+
+	_, err = shellCommands("curl 'a")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,227 @@
+package pica
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// StaticContentTypes responses of these media types, or types starting with them, are static
+// assets left out of har imports
+var StaticContentTypes = []string{
+	"text/html",
+	"text/css",
+	"text/javascript",
+	"application/javascript",
+	"application/x-javascript",
+	"application/wasm",
+	"application/font",
+	"application/manifest+json",
+	"image/",
+	"font/",
+	"audio/",
+	"video/",
+}
+
+// ignoredHeaders headers set by the http client or the cookie jar, left out of har imports
+var ignoredHeaders = map[string]bool{
+	"Host":              true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Accept-Encoding":   true,
+	"Cookie":            true,
+	"Keep-Alive":        true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+}
+
+var idSegment = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// HARScriptsGenerator turns the entries of a har file, like the ones saved by browser devtools,
+// into apis. Apis of the same method and path template are generated once, numeric and uuid
+// path segments become placeholders and every api asserts the recorded status.
+type HARScriptsGenerator struct {
+}
+
+func (generator *HARScriptsGenerator) Name() string {
+	return "har"
+}
+
+func (generator *HARScriptsGenerator) Generate(filename string) string {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		panic(err)
+	}
+	file := &harFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		panic(fmt.Errorf("har file %s: %s", filename, err.Error()))
+	}
+	if file.Log == nil {
+		panic(fmt.Errorf("har file %s has no log", filename))
+	}
+
+	var apis []*scriptAPI
+	seen := map[string]bool{}
+	for _, entry := range file.Log.Entries {
+		if entry.Request == nil || isStatic(entry) || isPreflight(entry) {
+			continue
+		}
+		api, err := harScriptAPI(entry)
+		if err != nil {
+			continue
+		}
+		key := api.Method + " " + strings.SplitN(api.Path, "?", 2)[0]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		apis = append(apis, api)
+	}
+	return writeScripts(apis)
+}
+
+// harScriptAPI the api of one entry, only http and https requests have one
+func harScriptAPI(entry *harEntry) (*scriptAPI, error) {
+	u, err := url.Parse(entry.Request.Url)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupport scheme %s", u.Scheme)
+	}
+	api := &scriptAPI{
+		Method:  strings.ToUpper(entry.Request.Method),
+		BaseUrl: u.Scheme + "://" + u.Host,
+		Headers: map[string]string{},
+	}
+
+	segments := strings.Split(u.EscapedPath(), "/")
+	var words []string
+	for index, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if idSegment.MatchString(segment) {
+			name := "id"
+			if len(api.Vars) > 0 {
+				name = fmt.Sprintf("id%d", len(api.Vars)+1)
+			}
+			value := segment
+			if strings.Contains(segment, "-") || len(segment) > 1 && segment[0] == '0' {
+				// ids have no quotes
+				value, _ = funnyString(segment)
+			}
+			api.Vars = append(api.Vars, scriptVar{Name: name, Value: value})
+			segments[index] = "<" + name + ">"
+			continue
+		}
+		if word, err := url.PathUnescape(segment); err == nil {
+			segment = word
+		}
+		words = append(words, segment)
+	}
+	api.Path = strings.Join(segments, "/")
+	if api.Path == "" {
+		api.Path = "/"
+	}
+	api.Name = apiName(api.Method, words, strings.HasSuffix(api.Path, ">"))
+	if u.RawQuery != "" {
+		api.Path += "?" + u.RawQuery
+	}
+
+	for _, header := range entry.Request.Headers {
+		name := http.CanonicalHeaderKey(header.Name)
+		if strings.HasPrefix(name, ":") || ignoredHeaders[name] {
+			continue
+		}
+		if value, ok := api.Headers[name]; ok {
+			api.Headers[name] = value + ", " + header.Value
+		} else {
+			api.Headers[name] = header.Value
+		}
+	}
+	if postData := entry.Request.PostData; postData != nil {
+		contentType := postData.MimeType
+		if contentType == "" {
+			contentType = api.Headers["Content-Type"]
+		}
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType == "multipart/form-data" {
+			// the boundary is made again by the runner
+			api.Headers["Content-Type"] = mediaType
+			api.Body = multipartBody(postData.Params)
+		} else {
+			api.Body = funnyBody(contentType, postData.Text)
+		}
+	}
+	if entry.Response != nil {
+		api.Status = entry.Response.Status
+	}
+	return api, nil
+}
+
+// multipartBody the map of the form fields, files are `@name` like the runner uploads
+func multipartBody(params []*harParam) string {
+	form := map[string]interface{}{}
+	for _, param := range params {
+		if param.FileName != "" {
+			form[param.Name] = "@" + param.FileName
+		} else {
+			form[param.Name] = param.Value
+		}
+	}
+	if len(form) == 0 {
+		return ""
+	}
+	return funnyMultipart(form)
+}
+
+// apiName names like getUsers or getUsersById from the method and the path words
+func apiName(method string, words []string, byId bool) string {
+	builder := &strings.Builder{}
+	builder.WriteString(strings.ToLower(method))
+	for _, word := range words {
+		for _, part := range strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			runes := []rune(part)
+			builder.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+		}
+	}
+	if byId {
+		builder.WriteString("ById")
+	}
+	return builder.String()
+}
+
+func isStatic(entry *harEntry) bool {
+	if entry.Response == nil || entry.Response.Content == nil {
+		return false
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(entry.Response.Content.MimeType, ";", 2)[0]))
+	for _, prefix := range StaticContentTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPreflight cors preflight requests are sent by browsers, not by the page
+func isPreflight(entry *harEntry) bool {
+	if !strings.EqualFold(entry.Request.Method, http.MethodOptions) {
+		return false
+	}
+	for _, header := range entry.Request.Headers {
+		if strings.EqualFold(header.Name, "Access-Control-Request-Method") {
+			return true
+		}
+	}
+	return false
+}
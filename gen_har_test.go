@@ -0,0 +1,165 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHARScriptsGenerator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pica-har")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	avatar := filepath.Join(dir, "avatar.png")
+	assert.Nil(t, ioutil.WriteFile(avatar, []byte("png"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Accept") != "application/json":
+			w.WriteHeader(400)
+		case r.Method == "POST" && r.URL.Path == "/api/users":
+			data, _ := ioutil.ReadAll(r.Body)
+			// headers funny has no strings for are left out, not sent changed
+			if string(data) != `{"age":3,"name":"pica","tags":["a"]}` || r.Header.Get("If-Match") != "" {
+				w.WriteHeader(400)
+				return
+			}
+			w.WriteHeader(201)
+		case r.Method == "PUT":
+			if _, _, err := r.FormFile("avatar"); err != nil || r.FormValue("name") != "me" {
+				w.WriteHeader(400)
+				return
+			}
+			w.WriteHeader(204)
+		case r.URL.Path == "/api/orders/7/items/0012" && r.Header.Get("X-Trace") == "":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	header := func(pairs ...string) []*harPair {
+		var headers []*harPair
+		for i := 0; i < len(pairs); i += 2 {
+			headers = append(headers, &harPair{Name: pairs[i], Value: pairs[i+1]})
+		}
+		return headers
+	}
+	common := []string{":authority", "api.example.com", "accept", "application/json", "cookie", "session=abc"}
+	entry := func(method, path string, status int, mimeType string, headers []*harPair, postData *harPostData) *harEntry {
+		return &harEntry{
+			Request: &harRequest{
+				Method:   method,
+				Url:      server.URL + path,
+				Headers:  headers,
+				PostData: postData,
+			},
+			Response: &harResponse{Status: status, Content: &harContent{MimeType: mimeType}},
+		}
+	}
+	har := &harFile{Log: &harLog{Version: "1.2", Entries: []*harEntry{
+		entry("GET", "/api/users?page=1", 200, "application/json", header(append(common, "x-trace", "1")...), nil),
+		entry("GET", "/api/users/42", 200, "application/json; charset=utf-8", header(common...), nil),
+		entry("GET", "/api/users/43", 200, "application/json", header(common...), nil),
+		entry("GET", "/static/app.js", 200, "application/javascript", header(common...), nil),
+		entry("OPTIONS", "/api/users", 204, "", header(append(common, "access-control-request-method", "POST")...), nil),
+		entry("POST", "/api/users", 201, "application/json", header(append(common, "content-type", "application/json", "if-match", "'abc'")...), &harPostData{
+			MimeType: "application/json",
+			Text:     `{"name": "pica", "age": 3, "tags": ["a"]}`,
+		}),
+		entry("PUT", "/api/users/6ba7b810-9dad-11d1-80b4-00c04fd430c8/avatar", 204, "", header(append(common, "content-type", "multipart/form-data; boundary=x")...), &harPostData{
+			MimeType: "multipart/form-data; boundary=x",
+			Params:   []*harParam{{Name: "avatar", FileName: avatar}, {Name: "name", Value: "me"}},
+		}),
+		entry("GET", "/api/orders/7/items/0012", 200, "application/json", header(common...), nil),
+		{Request: &harRequest{Method: "GET", Url: "data:image/png;base64,AA=="}},
+	}}}
+	data, err := json.Marshal(har)
+	assert.Nil(t, err)
+	filename := filepath.Join(dir, "session.har")
+	assert.Nil(t, ioutil.WriteFile(filename, data, 0644))
+
+	generator := NewScriptsGenerator("har")
+	assert.Equal(t, "har", generator.Name())
+	script := generator.Generate(filename)
+	assert.Equal(t, strings.Join([]string{
+		"baseUrl = '" + server.URL + "'",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"",
+		"// GET /api/users?page=1 getApiUsers",
+		"headers['X-Trace'] = '1'",
+		"// Response",
+		"assert(status == 200)",
+		"",
+		"// GET /api/users/<id> getApiUsersById",
+		"id = 42",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"// Response",
+		"assert(status == 200)",
+		"",
+		"// POST /api/users postApiUsers",
+		"// the header If-Match is left out, funny strings have no quotes or line breaks",
+		"headers['Content-Type'] = 'application/json'",
+		"body = {",
+		"    'age' = 3",
+		"    'name' = 'pica'",
+		"    'tags' = ['a']",
+		"}",
+		"// Response",
+		"assert(status == 201)",
+		"",
+		"// PUT /api/users/<id>/avatar putApiUsersAvatar",
+		"id = '6ba7b810-9dad-11d1-80b4-00c04fd430c8'",
+		"headers['Content-Type'] = 'multipart/form-data'",
+		"body = {",
+		"    'avatar' = '@" + avatar + "'",
+		"    'name' = 'me'",
+		"}",
+		"// Response",
+		"assert(status == 204)",
+		"",
+		"// GET /api/orders/<id>/items/<id2> getApiOrdersItemsById",
+		"id = 7",
+		"id2 = '0012'",
+		"headers = {",
+		"    'Accept' = 'application/json'",
+		"}",
+		"// Response",
+		"assert(status == 200)",
+		"",
+	}, "\n"), script)
+
+	runner := NewAPIRunnerFromContent([]byte(script))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, 5, len(runner.Results))
+}
+
+func TestApiName(t *testing.T) {
+	assert.Equal(t, "getApiUsersById", apiName("GET", []string{"api", "users"}, true))
+	assert.Equal(t, "postApi用户Éclair", apiName("POST", []string{"api", "用户", "éclair"}, false))
+}
+
+func TestFunnyBody(t *testing.T) {
+	assert.Equal(t, "{\n    'n' = 0 - 1\n    'z' = nil\n}", funnyBody("application/json", `{"n": -1, "z": null}`))
+	assert.Equal(t, `'{"price":1.5,"quote":"it\u0027s"}'`, funnyBody("application/json", `{"price": 1.5, "quote": "it's"}`))
+	assert.Equal(t, "{\n    'a' = '1'\n}", funnyBody("application/x-www-form-urlencoded", "a=1"))
+	assert.Equal(t, "'a=1&a=2'", funnyBody("application/x-www-form-urlencoded", "a=1&a=2"))
+	assert.Equal(t, "'<user/>'", funnyBody("application/xml", "<user/>"))
+	assert.Equal(t, "// the text/plain body is left out, funny strings have no quotes or line breaks", funnyBody("text/plain", "a\nb"))
+}
@@ -0,0 +1,317 @@
+package pica
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// HARReporter records every attempt of every request with its response into an
+// HTTP Archive 1.2 file, written when the outermost run ends
+type HARReporter struct {
+	writer  io.Writer
+	runs    runDepth
+	log     *harLog
+	httpReq *http.Request
+	sentAt  time.Time
+}
+
+func NewHARReporter(w io.Writer) *HARReporter {
+	return &HARReporter{writer: w}
+}
+
+type harFile struct {
+	Log *harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator *harCreator `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *harRequest  `json:"request"`
+	Response        *harResponse `json:"response"`
+	Cache           struct{}     `json:"cache"`
+	Timings         *harTimings  `json:"timings"`
+	Comment         string       `json:"comment,omitempty"`
+	// Error the error of attempts without a response, like devtools does
+	Error string `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string       `json:"method"`
+	Url         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []*harCookie `json:"cookies"`
+	Headers     []*harPair   `json:"headers"`
+	QueryString []*harPair   `json:"queryString"`
+	PostData    *harPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int          `json:"status"`
+	StatusText  string       `json:"statusText"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []*harCookie `json:"cookies"`
+	Headers     []*harPair   `json:"headers"`
+	Content     *harContent  `json:"content"`
+	RedirectURL string       `json:"redirectURL"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+type harPair struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type harPostData struct {
+	MimeType string      `json:"mimeType"`
+	Params   []*harParam `json:"params,omitempty"`
+	Text     string      `json:"text"`
+}
+
+// harParam a field of a posted form, FileName is set for files
+type harParam struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// harTimings in milliseconds, -1 for phases not happened. connect includes ssl.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+func (h *HARReporter) RunStart(name string, items []*ApiItem) {
+	if h.runs.start() {
+		h.log = &harLog{
+			Version: "1.2",
+			Creator: &harCreator{Name: "pica", Version: Version},
+			Entries: []*harEntry{},
+		}
+	}
+}
+
+func (h *HARReporter) ItemStart(item *ApiItem) {}
+
+func (h *HARReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	h.httpReq = httpReq
+	h.sentAt = time.Now()
+}
+
+// ResponseReceived records the request as sent, the client adds the cookies of the jar to its headers
+func (h *HARReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+	if h.log == nil || h.httpReq == nil {
+		return
+	}
+	entry := &harEntry{
+		StartedDateTime: h.sentAt.Format(time.RFC3339Nano),
+		Request:         newHARRequest(h.httpReq),
+		Response: &harResponse{
+			Cookies: []*harCookie{},
+			Headers: []*harPair{},
+			Content: &harContent{},
+		},
+		Timings: &harTimings{Blocked: -1, DNS: -1, Connect: -1, Wait: -1, Receive: -1, SSL: -1},
+		Comment: req.Name,
+	}
+	h.httpReq = nil
+	if err != nil {
+		entry.Error = err.Error()
+		h.log.Entries = append(h.log.Entries, entry)
+		return
+	}
+	entry.Request.HTTPVersion = res.Proto
+	entry.Response = newHARResponse(res, body)
+	if timing := ResponseTiming(res); timing != nil {
+		entry.StartedDateTime = timing.Start.Format(time.RFC3339Nano)
+		entry.Time = milliseconds(timing.Total)
+		entry.Timings = newHARTimings(timing)
+	}
+	h.log.Entries = append(h.log.Entries, entry)
+}
+
+func (h *HARReporter) AssertionResult(item *ApiItem, assertion *Assertion) {}
+
+func (h *HARReporter) ItemEnd(item *ApiItem, result *ApiResult) {}
+
+func (h *HARReporter) RunEnd(results []*ApiResult, err error) {
+	if !h.runs.end() {
+		return
+	}
+	data, _ := json.MarshalIndent(&harFile{Log: h.log}, "", "  ")
+	h.writer.Write(append(data, '\n'))
+}
+
+func newHARRequest(httpReq *http.Request) *harRequest {
+	request := &harRequest{
+		Method:      httpReq.Method,
+		Url:         httpReq.URL.String(),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*harCookie{},
+		Headers:     harHeaders(httpReq.Header),
+		QueryString: harQuery(httpReq.URL.Query()),
+		HeadersSize: -1,
+	}
+	for _, cookie := range httpReq.Cookies() {
+		request.Cookies = append(request.Cookies, &harCookie{Name: cookie.Name, Value: maskSecret(cookie.Value)})
+	}
+	if httpReq.GetBody == nil {
+		return request
+	}
+	reader, err := httpReq.GetBody()
+	if err != nil {
+		return request
+	}
+	body, _ := ioutil.ReadAll(reader)
+	request.BodySize = len(body)
+	mimeType := httpReq.Header.Get("Content-Type")
+	request.PostData = &harPostData{MimeType: mimeType}
+	if utf8.Valid(body) {
+		request.PostData.Text = string(body)
+	}
+	if strings.HasPrefix(mimeType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for _, pair := range harQuery(values) {
+				request.PostData.Params = append(request.PostData.Params, &harParam{Name: pair.Name, Value: pair.Value})
+			}
+		}
+	}
+	return request
+}
+
+// newHARResponse the response with its body as text, binary bodies are base64 encoded
+func newHARResponse(res *http.Response, body []byte) *harResponse {
+	response := &harResponse{
+		Status:      res.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(res.Status, strconv.Itoa(res.StatusCode))),
+		HTTPVersion: res.Proto,
+		Cookies:     []*harCookie{},
+		Headers:     harHeaders(res.Header),
+		Content: &harContent{
+			Size:     len(body),
+			MimeType: res.Header.Get("Content-Type"),
+		},
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	if utf8.Valid(body) {
+		response.Content.Text = string(body)
+	} else {
+		response.Content.Text = base64.StdEncoding.EncodeToString(body)
+		response.Content.Encoding = "base64"
+	}
+	for _, cookie := range res.Cookies() {
+		item := &harCookie{
+			Name:     cookie.Name,
+			Value:    maskSecret(cookie.Value),
+			Path:     cookie.Path,
+			Domain:   cookie.Domain,
+			HTTPOnly: cookie.HttpOnly,
+			Secure:   cookie.Secure,
+		}
+		if !cookie.Expires.IsZero() {
+			item.Expires = cookie.Expires.Format(time.RFC3339)
+		}
+		response.Cookies = append(response.Cookies, item)
+	}
+	return response
+}
+
+// newHARTimings the phases of timing, wait is the time to first byte after connecting
+func newHARTimings(timing *Timing) *harTimings {
+	timings := &harTimings{
+		Blocked: -1,
+		DNS:     -1,
+		Connect: -1,
+		SSL:     -1,
+		Wait:    milliseconds(timing.FirstByte - timing.DNS - timing.Connect - timing.TLS),
+		Receive: milliseconds(timing.Transfer),
+	}
+	if timing.DNS > 0 {
+		timings.DNS = milliseconds(timing.DNS)
+	}
+	if timing.Connect > 0 {
+		timings.Connect = milliseconds(timing.Connect + timing.TLS)
+	}
+	if timing.TLS > 0 {
+		timings.SSL = milliseconds(timing.TLS)
+	}
+	if timings.Wait < 0 {
+		timings.Wait = 0
+	}
+	return timings
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// harHeaders the headers sorted by name, secrets are masked
+func harHeaders(header http.Header) []*harPair {
+	pairs := harQuery(url.Values(header))
+	for _, pair := range pairs {
+		pair.Value = maskHeader(pair.Name, pair.Value)
+	}
+	return pairs
+}
+
+// harQuery the values sorted by name
+func harQuery(values url.Values) []*harPair {
+	pairs := []*harPair{}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range values[name] {
+			pairs = append(pairs, &harPair{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,101 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHARReporter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true})
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true}`))
+		case "/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte{0xff, 0xfe})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	har := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + `
+// POST /login login
+headers = {
+    'Content-Type' = 'application/x-www-form-urlencoded'
+    'Authorization' = 'Basic cGljYTpwaWNh'
+}
+post = {
+    name = 'pica'
+}
+
+// GET /binary?size=2 binary
+
+// GET /missing missing
+// retries: 1
+// retryOn: 404
+`))
+	runner.Reporter = NewHARReporter(har)
+	assert.Nil(t, runner.Run())
+
+	file := &harFile{}
+	assert.Nil(t, json.Unmarshal(har.Bytes(), file))
+	assert.Equal(t, "1.2", file.Log.Version)
+	assert.Equal(t, "pica", file.Log.Creator.Name)
+	// every attempt is an entry
+	assert.Equal(t, 4, len(file.Log.Entries))
+
+	login := file.Log.Entries[0]
+	assert.Equal(t, "login", login.Comment)
+	_, err := time.Parse(time.RFC3339Nano, login.StartedDateTime)
+	assert.Nil(t, err)
+	assert.Equal(t, "POST", login.Request.Method)
+	assert.Equal(t, server.URL+"/login", login.Request.Url)
+	assert.Equal(t, "HTTP/1.1", login.Request.HTTPVersion)
+	assert.Equal(t, []*harParam{{Name: "name", Value: "pica"}}, login.Request.PostData.Params)
+	assert.Equal(t, "name=pica", login.Request.PostData.Text)
+	assert.Equal(t, 200, login.Response.Status)
+	assert.Equal(t, "OK", login.Response.StatusText)
+	assert.Equal(t, `{"ok":true}`, login.Response.Content.Text)
+	assert.Equal(t, "session", login.Response.Cookies[0].Name)
+	assert.Equal(t, "***", login.Response.Cookies[0].Value)
+	assert.Contains(t, login.Response.Headers, &harPair{Name: "Set-Cookie", Value: "session=***; Path=/; HttpOnly"})
+	assert.NotContains(t, har.String(), "cGljYTpwaWNh")
+	assert.True(t, login.Response.Cookies[0].HTTPOnly)
+	assert.True(t, login.Time > 0)
+	assert.Equal(t, float64(-1), login.Timings.SSL)
+
+	binary := file.Log.Entries[1]
+	assert.Equal(t, []*harPair{{Name: "size", Value: "2"}}, binary.Request.QueryString)
+	// the cookies of the jar are recorded, masked like the secret headers
+	assert.Equal(t, "session", binary.Request.Cookies[0].Name)
+	assert.Equal(t, "***", binary.Request.Cookies[0].Value)
+	assert.Contains(t, binary.Request.Headers, &harPair{Name: "Authorization", Value: "Basic ***"})
+	assert.Contains(t, binary.Request.Headers, &harPair{Name: "Cookie", Value: "session=***"})
+	assert.Equal(t, "base64", binary.Response.Content.Encoding)
+	assert.Equal(t, "//4=", binary.Response.Content.Text)
+
+	assert.Equal(t, 404, file.Log.Entries[3].Response.Status)
+	assert.Equal(t, "Not Found", file.Log.Entries[3].Response.StatusText)
+
+	ShowSecrets = true
+	defer func() { ShowSecrets = false }()
+	har.Reset()
+	runner = NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + `
+// POST /login login
+`))
+	runner.Reporter = NewHARReporter(har)
+	assert.Nil(t, runner.Run())
+	file = &harFile{}
+	assert.Nil(t, json.Unmarshal(har.Bytes(), file))
+	assert.Equal(t, "abc", file.Log.Entries[0].Response.Cookies[0].Value)
+}
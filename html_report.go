@@ -0,0 +1,223 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "github.com/jerloo/pica/statik"
+	"github.com/rakyll/statik/fs"
+)
+
+// HTMLReporter writes a single file html report when the outermost run ends, with a summary,
+// the full request, response, assertions and timing of every item, and filters for failed items
+type HTMLReporter struct {
+	writer   io.Writer
+	template *template.Template
+	runs     runDepth
+	report   *htmlReport
+	file     string
+	current  *htmlItem
+}
+
+func NewHTMLReporter(w io.Writer) *HTMLReporter {
+	return &HTMLReporter{
+		writer:   w,
+		template: reportTemplate(),
+	}
+}
+
+// reportTemplate the template of the html report in the assets
+func reportTemplate() *template.Template {
+	statikFS, err := fs.New()
+	if err != nil {
+		panic(err)
+	}
+	file, err := statikFS.Open("/report_template.html")
+	if err != nil {
+		panic(err)
+	}
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		panic(err)
+	}
+	return template.Must(template.New("report").Parse(string(data)))
+}
+
+type htmlReport struct {
+	Name      string
+	StartedAt string
+	Duration  time.Duration
+	Passed    int
+	Failed    int
+	Error     string
+	Items     []*htmlItem
+
+	start time.Time
+}
+
+type htmlItem struct {
+	Name       string
+	Method     string
+	Url        string
+	File       string
+	State      string
+	Status     int
+	Error      string
+	Duration   time.Duration
+	Attempts   int
+	Request    *htmlMessage
+	Response   *htmlMessage
+	Assertions []*Assertion
+	Timing     *Timing
+}
+
+// htmlMessage a request or a response, headers are lines like `Name: value`
+type htmlMessage struct {
+	Method  string
+	Url     string
+	Proto   string
+	Status  string
+	Headers []string
+	Body    string
+}
+
+func (h *HTMLReporter) RunStart(name string, items []*ApiItem) {
+	if h.runs.start() {
+		if name == "" {
+			name = "pica"
+		}
+		now := time.Now()
+		h.report = &htmlReport{
+			Name:      filepath.Base(name),
+			StartedAt: now.Format("2006-01-02 15:04:05"),
+			start:     now,
+		}
+		return
+	}
+	// tasks show the file of every item
+	h.file = filepath.Base(name)
+}
+
+func (h *HTMLReporter) ItemStart(item *ApiItem) {
+	h.current = &htmlItem{File: h.file}
+}
+
+func (h *HTMLReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	if h.current == nil {
+		return
+	}
+	var body []byte
+	if httpReq.GetBody != nil {
+		if reader, err := httpReq.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(reader)
+		}
+	}
+	h.current.Attempts = attempt
+	h.current.Request = &htmlMessage{
+		Method:  httpReq.Method,
+		Url:     httpReq.URL.String(),
+		Headers: headerLines(httpReq.Header),
+		Body:    prettyBody(httpReq.Header.Get("Content-Type"), body),
+	}
+}
+
+func (h *HTMLReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+	if h.current == nil {
+		return
+	}
+	if err != nil {
+		h.current.Response = nil
+		return
+	}
+	h.current.Response = &htmlMessage{
+		Proto:   res.Proto,
+		Status:  res.Status,
+		Headers: headerLines(res.Header),
+		Body:    prettyBody(res.Header.Get("Content-Type"), body),
+	}
+}
+
+func (h *HTMLReporter) AssertionResult(item *ApiItem, assertion *Assertion) {}
+
+func (h *HTMLReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	current := h.current
+	h.current = nil
+	if current == nil || h.report == nil {
+		return
+	}
+	current.Name = result.Name
+	current.Method = result.Method
+	current.Url = result.Url
+	current.Status = result.Status
+	current.Error = result.Error
+	current.Duration = result.Duration
+	current.Assertions = result.Assertions
+	current.Timing = result.Timing
+	switch {
+	case result.Skipped:
+		current.State = "skipped failed"
+	case result.Passed:
+		current.State = "passed"
+	default:
+		current.State = "failed"
+	}
+	if result.Passed {
+		h.report.Passed++
+	} else {
+		h.report.Failed++
+	}
+	h.report.Items = append(h.report.Items, current)
+}
+
+func (h *HTMLReporter) RunEnd(results []*ApiResult, err error) {
+	if !h.runs.end() {
+		h.file = ""
+		return
+	}
+	h.report.Duration = time.Since(h.report.start).Round(time.Millisecond)
+	if err != nil {
+		h.report.Error = err.Error()
+	}
+	if execErr := h.template.Execute(h.writer, h.report); execErr != nil {
+		fmt.Fprintf(h.writer, "<!-- %s -->\n", execErr.Error())
+	}
+}
+
+// headerLines the headers sorted by name, secrets are masked
+func headerLines(header http.Header) []string {
+	var lines []string
+	for name, values := range header {
+		for _, value := range values {
+			lines = append(lines, name+": "+maskHeader(name, value))
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// prettyBody the body indented when it is json, or the size of binary bodies
+func prettyBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if strings.Contains(contentType, "json") {
+		indented := new(bytes.Buffer)
+		if json.Indent(indented, body, "", "  ") == nil {
+			return indented.String()
+		}
+	}
+	if !utf8.Valid(body) {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return string(body)
+}
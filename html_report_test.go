@@ -0,0 +1,79 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"<pica>"}`))
+	}))
+	defer server.Close()
+
+	report := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + `
+// POST /users createUser
+headers['Authorization'] = 'Bearer s3cret'
+headers['Cookie'] = 'session=abc; theme=dark'
+post = {
+    name = 'pica'
+}
+// Response
+expectEqual(status, 200)
+
+// GET /missing getMissing
+// Response
+expectEqual(status, 200)
+`))
+	runner.Reporter = NewHTMLReporter(report)
+	assert.NotNil(t, runner.Run())
+
+	html := report.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, `<strong class="passed-text">1</strong>passed`)
+	assert.Contains(t, html, `<strong class="failed-text">1</strong>failed`)
+	assert.Contains(t, html, `<details class="item passed" >`)
+	assert.Contains(t, html, `<details class="item failed" open>`)
+	assert.Contains(t, html, "POST "+server.URL+"/users")
+	assert.Contains(t, html, "Content-Type: application/json")
+	assert.Contains(t, html, "{\n  &#34;name&#34;: &#34;pica&#34;\n}")
+	// bodies are indented and escaped
+	assert.Contains(t, html, "{\n  &#34;name&#34;: &#34;&lt;pica&gt;&#34;\n}")
+	assert.Contains(t, html, "HTTP/1.1 404 Not Found")
+	assert.Contains(t, html, "expected 200, actual 404")
+	assert.Contains(t, html, "first byte")
+	// secrets are masked
+	assert.Contains(t, html, "Authorization: Bearer ***")
+	assert.Contains(t, html, "Cookie: session=***; theme=***")
+	assert.NotContains(t, html, "s3cret")
+
+	ShowSecrets = true
+	defer func() { ShowSecrets = false }()
+	report.Reset()
+	runner = NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + `
+// GET /users listUsers
+headers['Authorization'] = 'Bearer s3cret'
+`))
+	runner.Reporter = NewHTMLReporter(report)
+	assert.Nil(t, runner.Run())
+	assert.Contains(t, report.String(), "Authorization: Bearer s3cret")
+}
+
+func TestMaskHeader(t *testing.T) {
+	assert.Equal(t, "Basic ***", maskHeader("authorization", "Basic cGljYTpwaWNh"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 ***", maskHeader("Authorization", "AWS4-HMAC-SHA256 Credential=AKID/20260101/us-east-1/s3/aws4_request, Signature=abc"))
+	assert.Equal(t, "***", maskHeader("X-Signature", "0a1b2c"))
+	assert.Equal(t, "session=***; Path=/; HttpOnly", maskHeader("Set-Cookie", "session=abc; Path=/; HttpOnly"))
+	assert.Equal(t, "application/json", maskHeader("Content-Type", "application/json"))
+}
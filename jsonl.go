@@ -0,0 +1,104 @@
+package pica
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// JSONLinesReporter writes every event as one json object per line, like
+//
+//	{"event":"itemEnd","time":"2021-10-01T12:00:00Z","name":"getUser","result":{...}}
+type JSONLinesReporter struct {
+	encoder *json.Encoder
+}
+
+func NewJSONLinesReporter(w io.Writer) *JSONLinesReporter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &JSONLinesReporter{encoder: encoder}
+}
+
+// jsonEvent one line of the JSONLinesReporter, fields not of the event are omitted
+type jsonEvent struct {
+	Event     string     `json:"event"`
+	Time      string     `json:"time"`
+	Name      string     `json:"name,omitempty"`
+	Items     []string   `json:"items,omitempty"`
+	Method    string     `json:"method,omitempty"`
+	Url       string     `json:"url,omitempty"`
+	Attempt   int        `json:"attempt,omitempty"`
+	Status    int        `json:"status,omitempty"`
+	Proto     string     `json:"proto,omitempty"`
+	Size      int        `json:"size,omitempty"`
+	Timing    *Timing    `json:"timing,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Assertion *Assertion `json:"assertion,omitempty"`
+	Result    *ApiResult `json:"result,omitempty"`
+	Passed    *int       `json:"passed,omitempty"`
+	Failed    *int       `json:"failed,omitempty"`
+}
+
+func (j *JSONLinesReporter) write(event *jsonEvent) {
+	event.Time = time.Now().Format(time.RFC3339Nano)
+	j.encoder.Encode(event)
+}
+
+func (j *JSONLinesReporter) RunStart(name string, items []*ApiItem) {
+	event := &jsonEvent{Event: "runStart", Name: name}
+	for _, item := range items {
+		event.Items = append(event.Items, item.Request.Name)
+	}
+	j.write(event)
+}
+
+func (j *JSONLinesReporter) ItemStart(item *ApiItem) {
+	j.write(&jsonEvent{Event: "itemStart", Name: item.Request.Name, Method: item.Request.Method, Url: item.Request.Url})
+}
+
+func (j *JSONLinesReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	j.write(&jsonEvent{Event: "requestSent", Name: req.Name, Method: httpReq.Method, Url: httpReq.URL.String(), Attempt: attempt})
+}
+
+func (j *JSONLinesReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+	event := &jsonEvent{Event: "responseReceived", Name: req.Name}
+	if err != nil {
+		event.Error = err.Error()
+	} else {
+		event.Status = res.StatusCode
+		event.Proto = res.Proto
+		event.Size = len(body)
+		event.Timing = ResponseTiming(res)
+	}
+	j.write(event)
+}
+
+func (j *JSONLinesReporter) AssertionResult(item *ApiItem, assertion *Assertion) {
+	j.write(&jsonEvent{Event: "assertion", Name: item.Request.Name, Assertion: assertion})
+}
+
+func (j *JSONLinesReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	j.write(&jsonEvent{Event: "itemEnd", Name: item.Request.Name, Result: result})
+}
+
+func (j *JSONLinesReporter) RunEnd(results []*ApiResult, err error) {
+	passed, failed := countResults(results)
+	event := &jsonEvent{Event: "runEnd", Passed: &passed, Failed: &failed}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	j.write(event)
+}
+
+// countResults the count of the passed and the failed results
+func countResults(results []*ApiResult) (passed, failed int) {
+	for _, result := range results {
+		if result.Passed {
+			passed++
+		} else {
+			failed++
+		}
+	}
+	return
+}
@@ -0,0 +1,141 @@
+package pica
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// JUnitReporter writes a JUnit xml document when the outermost run ends,
+// a testsuite for every file and a testcase for every item
+type JUnitReporter struct {
+	writer io.Writer
+	runs   runDepth
+	suites []*junitSuite
+	name   string
+	suite  *junitSuite
+}
+
+func NewJUnitReporter(w io.Writer) *JUnitReporter {
+	return &JUnitReporter{writer: w}
+}
+
+type junitSuites struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Skipped  int           `xml:"skipped,attr"`
+	Time     string        `xml:"time,attr"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string       `xml:"name,attr"`
+	Tests     int          `xml:"tests,attr"`
+	Failures  int          `xml:"failures,attr"`
+	Skipped   int          `xml:"skipped,attr"`
+	Time      string       `xml:"time,attr"`
+	Timestamp string       `xml:"timestamp,attr"`
+	Cases     []*junitCase `xml:"testcase"`
+
+	duration time.Duration
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func (j *JUnitReporter) RunStart(name string, items []*ApiItem) {
+	if j.runs.start() {
+		j.suites = nil
+	}
+	// the suite of the innermost run is created by its first item, so tasks have the suites of their files
+	if name == "" {
+		name = "pica"
+	}
+	j.name = filepath.Base(name)
+	j.suite = nil
+}
+
+func (j *JUnitReporter) ItemStart(item *ApiItem) {}
+
+func (j *JUnitReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {}
+
+func (j *JUnitReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+}
+
+func (j *JUnitReporter) AssertionResult(item *ApiItem, assertion *Assertion) {}
+
+func (j *JUnitReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	if j.suite == nil {
+		j.suite = &junitSuite{Name: j.name, Timestamp: time.Now().Format("2006-01-02T15:04:05")}
+		j.suites = append(j.suites, j.suite)
+	}
+	suite := j.suite
+	suite.duration += result.Duration
+	name := result.Name
+	if name == "" {
+		name = result.Method + " " + result.Url
+	}
+	testCase := &junitCase{
+		Name:      name,
+		Classname: strings.TrimSuffix(suite.Name, filepath.Ext(suite.Name)),
+		Time:      junitSeconds(result.Duration),
+	}
+	suite.Tests++
+	switch {
+	case result.Skipped:
+		suite.Skipped++
+		testCase.Skipped = &junitMessage{Message: result.Error}
+	case !result.Passed:
+		suite.Failures++
+		message := result.Error
+		if index := strings.Index(message, "\n"); index >= 0 {
+			message = message[:index]
+		}
+		testCase.Failure = &junitMessage{Message: message, Type: "failure", Text: result.Error}
+	}
+	suite.Cases = append(suite.Cases, testCase)
+}
+
+func (j *JUnitReporter) RunEnd(results []*ApiResult, err error) {
+	if !j.runs.end() {
+		return
+	}
+	doc := &junitSuites{}
+	var total time.Duration
+	for _, suite := range j.suites {
+		suite.Time = junitSeconds(suite.duration)
+		total += suite.duration
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+	doc.Time = junitSeconds(total)
+	data, marshalErr := xml.MarshalIndent(doc, "", "  ")
+	if marshalErr != nil {
+		return
+	}
+	io.WriteString(j.writer, xml.Header)
+	j.writer.Write(data)
+	io.WriteString(j.writer, "\n")
+}
@@ -0,0 +1,76 @@
+package pica
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// APIInfo the summary of one api item
+type APIInfo struct {
+	Method      string
+	Path        string
+	Name        string
+	Description string
+	Tags        []string
+}
+
+// FileInfo the apis of one pica file
+type FileInfo struct {
+	File    string
+	APIs    []*APIInfo
+	Total   int
+	Methods map[string]int
+}
+
+// Summary one line summary like "3 apis (GET 2, POST 1)" of the listed apis
+func (info *FileInfo) Summary() string {
+	var methods []string
+	for method := range info.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for index, method := range methods {
+		methods[index] = fmt.Sprintf("%s %d", method, info.Methods[method])
+	}
+	return fmt.Sprintf("%d apis (%s)", len(info.APIs), strings.Join(methods, ", "))
+}
+
+// ListAPIs parse the pica file without running it and collect the apis matched by selector
+func ListAPIs(filename string, selector *Selector) (info *FileInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("parse %s error %v", filename, r)
+		}
+	}()
+	runner := NewAPIRunnerFromFile(filename, nil, 0)
+	err = runner.Parse()
+	if err != nil {
+		return nil, err
+	}
+	err = runner.ParseAPIItems()
+	if err != nil {
+		return nil, err
+	}
+	info = &FileInfo{
+		File:    filename,
+		APIs:    []*APIInfo{},
+		Total:   len(runner.APIItems),
+		Methods: map[string]int{},
+	}
+	for _, item := range runner.APIItems {
+		if !selector.Match(item.Request) {
+			continue
+		}
+		method := strings.ToUpper(item.Request.Method)
+		info.APIs = append(info.APIs, &APIInfo{
+			Method:      method,
+			Path:        item.Request.Url,
+			Name:        item.Request.Name,
+			Description: item.Request.Description,
+			Tags:        item.Request.Tags,
+		})
+		info.Methods[method]++
+	}
+	return info, nil
+}
@@ -0,0 +1,55 @@
+package pica
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const listTestFile = `
+baseUrl = 'http://localhost'
+
+// GET /api/users listUsers list all users @smoke @read
+query = {}
+
+// POST /api/users createUser @write
+post = {}
+
+// DELETE /api/users/<id> deleteUser @write
+`
+
+func TestListAPIs(t *testing.T) {
+	file, err := ioutil.TempFile("", "pica*.fun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(listTestFile)
+	file.Close()
+
+	info, err := ListAPIs(file.Name(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(info.APIs))
+	assert.Equal(t, "listUsers", info.APIs[0].Name)
+	assert.Equal(t, "list all users", info.APIs[0].Description)
+	assert.Equal(t, []string{"smoke", "read"}, info.APIs[0].Tags)
+	assert.Equal(t, "3 apis (DELETE 1, GET 1, POST 1)", info.Summary())
+
+	info, err = ListAPIs(file.Name(), &Selector{Tags: []string{"write"}, PathGlob: "/api/users/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(info.APIs))
+	assert.Equal(t, "deleteUser", info.APIs[0].Name)
+	assert.Equal(t, 3, info.Total)
+
+	info, err = ListAPIs(file.Name(), &Selector{Method: "post"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "createUser", info.APIs[0].Name)
+}
@@ -7,49 +7,183 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/jerloo/funny"
 	"github.com/mitchellh/go-homedir"
 )
 
+// ProjectFile the default filename of a pica project manifest
+const ProjectFile = "pica.json"
+
+// Task one step of a named task, runs the apis of File selected by Names and Tags,
+// or all of them if both are empty
 type Task struct {
+	File  string
 	Names []string
+	Tags  []string
+}
+
+// TaskResult the results of the last run of a named task
+type TaskResult struct {
+	LastRunAt string
+	Duration  time.Duration
+	Passed    int
+	Failed    int
+	Error     string
+	Items     []*ApiResult
 }
 
+// Project a pica project manifest, stored as pica.json
 type Project struct {
 	Name      string
 	Version   string
 	Author    string
 	CreatedAt string
 	LastRunAt string
-	Tasks     map[string][]*Task
+
+	// Files the pica files of the project, the steps of tasks can only run these when set
+	Files        []string
+	Environments map[string]map[string]funny.Value
+	Tasks        map[string][]*Task
+	Results      map[string]*TaskResult
+
+	// Reporter receives the events of the tasks, DefaultOutput when nil
+	Reporter Reporter `json:"-"`
+
+	filename string
 }
 
 func NewProject(name, version, author, created, lastRunAt string) *Project {
 	return &Project{
-		Name:      name,
-		Version:   version,
-		Author:    author,
-		CreatedAt: created,
-		LastRunAt: lastRunAt,
+		Name:         name,
+		Version:      version,
+		Author:       author,
+		CreatedAt:    created,
+		LastRunAt:    lastRunAt,
+		Environments: map[string]map[string]funny.Value{},
+		Tasks:        map[string][]*Task{},
+		Results:      map[string]*TaskResult{},
+		filename:     ProjectFile,
+	}
+}
+
+// LoadProject load a project manifest from filename
+func LoadProject(filename string) (*Project, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p := NewProject("", "", "", "", "")
+	err = json.Unmarshal(data, p)
+	if err != nil {
+		return nil, fmt.Errorf("parse project %s error %s", filename, err.Error())
 	}
+	p.filename = filename
+	return p, nil
 }
 
 func (p *Project) Save() error {
-	data, err := json.Marshal(p)
+	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile("pica.json", data, os.ModePerm)
+	return ioutil.WriteFile(p.filename, data, os.ModePerm)
+}
+
+// RunTask run the steps of task name in order, all files share one vm so variables
+// assigned by earlier apis are visible to the later ones.
+// The variables of environment env are assigned after the init lines of each file.
+func (p *Project) RunTask(name, env string) (*TaskResult, error) {
+	steps, ok := p.Tasks[name]
+	if !ok {
+		return nil, fmt.Errorf("task [%s] not found", name)
+	}
+	if err := p.checkFiles(name, steps); err != nil {
+		return nil, err
+	}
+	var variables map[string]funny.Value
+	if env != "" {
+		variables, ok = p.Environments[env]
+		if !ok {
+			return nil, fmt.Errorf("environment [%s] not found", env)
+		}
+	}
+
+	start := time.Now()
+	result := &TaskResult{
+		LastRunAt: start.Format(time.RFC3339),
+	}
+	// the steps share the variables, the cookies and the tokens, like a session logged in by the first one
+	vm := newFunny(newInitScope())
+	jar := NewCookieJar()
+	tokens := newTokenCache()
+	reporter := p.Reporter
+	if reporter == nil {
+		reporter = DefaultOutput
+	}
+	reporter.RunStart(name, nil)
+	var err error
+	for _, step := range steps {
+		runner := NewAPIRunnerFromFile(p.resolve(step.File), step.Names, 0)
+		runner.Selector = &Selector{Tags: step.Tags}
+		runner.vm = vm
+		runner.jar = jar
+		runner.tokens = tokens
+		runner.Reporter = reporter
+		runner.Variables = variables
+		err = runner.Run()
+		for _, item := range runner.Results {
+			if item.Passed {
+				result.Passed++
+			} else {
+				result.Failed++
+			}
+		}
+		result.Items = append(result.Items, runner.Results...)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+	}
+	result.Duration = time.Since(start)
+	reporter.RunEnd(result.Items, err)
+
+	p.LastRunAt = result.LastRunAt
+	p.Results[name] = result
+	if saveErr := p.Save(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return result, err
 }
 
-//func (p *Project) RunTask(name string) error {
-//	for key, val := range p.Tasks{
-//		if key == name{
-//			apiRunner := NewApiRunnerFromFile()
-//		}
-//	}
-//}
+// checkFiles the files of the steps must be listed in Files, when the project lists any
+func (p *Project) checkFiles(name string, steps []*Task) error {
+	if len(p.Files) == 0 {
+		return nil
+	}
+	for _, step := range steps {
+		listed := false
+		for _, file := range p.Files {
+			if filepath.Clean(file) == filepath.Clean(step.File) {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			return fmt.Errorf("file [%s] of task [%s] is not in the files of the project", step.File, name)
+		}
+	}
+	return nil
+}
+
+// resolve file paths relative to the directory of the manifest
+func (p *Project) resolve(filename string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+	return filepath.Join(filepath.Dir(p.filename), filename)
+}
 
 type ApiRequest struct {
 	Headers     http.Header
@@ -58,8 +192,13 @@ type ApiRequest struct {
 	Query       Query
 	Name        string
 	Description string
+	Tags        []string
+	Depends     []string
+	Annotations map[string]string
 	Body        []byte
 	lines       funny.Block
+	// body the `body` variable assigned by the request lines
+	body funny.Value
 }
 
 type ApiResponse struct {
@@ -71,6 +210,44 @@ type ApiResponse struct {
 	saveLines funny.Block
 }
 
+// ApiResult the result of running one api item
+type ApiResult struct {
+	Name   string
+	Method string
+	Url    string
+	Status int
+	Passed bool
+	// Skipped items never ran, like those depending on a failed one
+	Skipped    bool
+	Error      string
+	Duration   time.Duration
+	Timing     *Timing
+	Attempts   []*Attempt
+	Assertions []*Assertion
+	// DecodeError the error of decoding the response, whose variable like `json` is nil then
+	DecodeError string
+}
+
+// Attempt one try of sending an api request
+type Attempt struct {
+	Status   int
+	Error    string
+	Duration time.Duration
+}
+
+func newAttempt(res *http.Response, err error, duration time.Duration) *Attempt {
+	attempt := &Attempt{
+		Duration: duration,
+	}
+	if res != nil {
+		attempt.Status = res.StatusCode
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	return attempt
+}
+
 type ApiItem struct {
 	Request  *ApiRequest
 	Response *ApiResponse
@@ -104,6 +281,18 @@ var DefaultInitScope = map[string]funny.Value{
 	"headers": DefaultHeaders,
 }
 
+// newInitScope copy DefaultInitScope so runners never share variables
+func newInitScope() funny.Scope {
+	scope := funny.Scope{}
+	for k, v := range DefaultInitScope {
+		if m, ok := v.(map[string]funny.Value); ok {
+			v = copyMap(m)
+		}
+		scope[k] = v
+	}
+	return scope
+}
+
 var PROFILE_HOME = ""
 
 func init() {
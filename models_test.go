@@ -0,0 +1,98 @@
+package pica
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jerloo/funny"
+	"github.com/stretchr/testify/assert"
+)
+
+const projectTestCreateFile = `
+baseUrl = 'http://localhost'
+
+// POST /api/users createUser
+post = {
+  name = 'test'
+}
+// Response
+assert(status == 200)
+id = json.id
+`
+
+const projectTestGetFile = `
+// GET /api/users/<id> getUser
+query = {}
+// Response
+assert(status == 200)
+assert(json.id == id)
+`
+
+func TestProject_RunTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "10"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "create.funny"), []byte(projectTestCreateFile), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(dir, "get.funny"), []byte(projectTestGetFile), os.ModePerm)
+
+	project := NewProject("test", "0.0.1", "pica", "", "")
+	project.filename = filepath.Join(dir, ProjectFile)
+	project.Files = []string{"create.funny", "get.funny"}
+	project.Environments["local"] = map[string]funny.Value{
+		"baseUrl": server.URL,
+	}
+	project.Tasks["smoke"] = []*Task{
+		{File: "create.funny", Names: []string{"createUser"}},
+		{File: "get.funny"},
+	}
+	err = project.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err = LoadProject(filepath.Join(dir, ProjectFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	junit := new(bytes.Buffer)
+	project.Reporter = NewJUnitReporter(junit)
+	result, err := project.RunTask("smoke", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, result.Passed)
+	assert.Equal(t, 0, result.Failed)
+	// one document with the suites of the files
+	assert.Equal(t, 1, strings.Count(junit.String(), "<testsuites "))
+	assert.Contains(t, junit.String(), `<testsuite name="create.funny" tests="1"`)
+	assert.Contains(t, junit.String(), `<testsuite name="get.funny" tests="1"`)
+
+	saved, err := LoadProject(filepath.Join(dir, ProjectFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, result.LastRunAt, saved.LastRunAt)
+	assert.Equal(t, 2, len(saved.Results["smoke"].Items))
+
+	_, err = project.RunTask("smoke", "prod")
+	assert.NotNil(t, err)
+
+	// steps only run the files of the project
+	project.Tasks["orders"] = []*Task{{File: "orders.funny"}}
+	_, err = project.RunTask("orders", "local")
+	assert.EqualError(t, err, "file [orders.funny] of task [orders] is not in the files of the project")
+}
@@ -1,6 +1,8 @@
 package pica
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,17 +10,21 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/hokaccha/go-prettyjson"
+	"github.com/jerloo/funny"
 	_ "github.com/jerloo/pica/statik"
 	"github.com/rakyll/statik/fs"
 )
 
+// Output the pretty console Reporter, printing the requests and responses in full
 type Output struct {
 	Debug            bool
 	DefaultLineCount int
 	writer           io.Writer
+	runs             runDepth
 }
 
 func NewOutput(debug bool, writer io.Writer) *Output {
@@ -56,35 +62,162 @@ func (o *Output) RepeatLine(e string, count int) string {
 	return "\n" + strings.Repeat(e, count)
 }
 
-func (o *Output) EchoStartRequest(request *ApiRequest, runner *APIRunner) error {
-	fmt.Println(o.L("="))
-	fmt.Println()
-	color.Green("%s %s %s", request.Method, request.Url, request.Name)
-	targetUrl, err := getTargetURL(request, runner)
+// Color write a colored line to the writer, like color.Green does to stdout
+func (o *Output) Color(attr color.Attribute, format string, args ...interface{}) {
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	c := color.New(attr)
+	if !colored(o.writer) {
+		c.DisableColor()
+	}
+	c.Fprintf(o.writer, format, args...)
+}
+
+// RunStart implements Reporter
+func (o *Output) RunStart(name string, items []*ApiItem) {
+	o.runs.start()
+}
+
+// ItemStart implements Reporter
+func (o *Output) ItemStart(item *ApiItem) {}
+
+// RequestSent echo the url, headers and body of the first attempt, and the later attempts as retries
+func (o *Output) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	if attempt > 1 {
+		o.Color(color.FgYellow, "\nRetry %d", attempt)
+		return
+	}
+	fmt.Fprintln(o.writer, o.L("="))
+	fmt.Fprintln(o.writer)
+	o.Color(color.FgGreen, "%s %s %s", req.Method, req.Url, req.Name)
+	o.Color(color.FgBlue, "\nRequest %s\n\n", httpReq.URL)
+	o.Headers(httpReq.Header)
+	o.RequestBody(httpReq)
+}
+
+// ResponseReceived echo the redirects, status, headers and decoded body of a response,
+// with the tls connection and the timing in debug mode
+func (o *Output) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
 	if err != nil {
-		return err
+		o.ErrorRequest(err)
+		return
+	}
+	for _, hop := range redirectChain(res) {
+		hop := hop.(map[string]funny.Value)
+		o.Redirect(hop["status"].(int), hop["location"].(string))
+	}
+	o.Status(res.StatusCode)
+	if res.TLS != nil && o.Debug {
+		o.TLS(res.TLS)
+	}
+	o.Headers(res.Header)
+
+	decoder, doc, err := decodeResponse(&ApiResponse{Headers: res.Header, Body: body, Status: res.StatusCode})
+	if err != nil {
+		o.Error(fmt.Errorf("%s binding %s %s", decoder.Name, err.Error(), body))
+	}
+	if text, ok := doc.(string); ok || doc == nil {
+		if !ok {
+			text = string(body)
+		}
+		o.Echo(text)
+	} else {
+		o.Json(doc)
+	}
+	if timing := ResponseTiming(res); timing != nil && o.Debug {
+		o.Timing(timing)
+	}
+}
+
+// AssertionResult implements Reporter
+func (o *Output) AssertionResult(item *ApiItem, assertion *Assertion) {
+	o.Assertion(assertion)
+}
+
+// ItemEnd implements Reporter
+func (o *Output) ItemEnd(item *ApiItem, result *ApiResult) {
+	if result.DecodeError != "" {
+		o.Color(color.FgYellow, "\n%s\n", result.DecodeError)
+	}
+}
+
+// RunEnd echo a summary of the results when the outermost run ends
+func (o *Output) RunEnd(results []*ApiResult, err error) {
+	if !o.runs.end() || len(results) == 0 {
+		return
+	}
+	passed, failed := countResults(results)
+	fmt.Fprintln(o.writer, o.L("="))
+	if failed > 0 {
+		o.Color(color.FgRed, "\nFinished. [%d] api requests, [%d] passed, [%d] failed", len(results), passed, failed)
+	} else {
+		o.Color(color.FgGreen, "\nFinished. [%d] api requests, [%d] passed", len(results), passed)
 	}
-	color.Blue("\nRequest %s\n\n", targetUrl)
-	return nil
 }
 
 func (o *Output) ErrorRequest(err error) {
-	color.Red("do http request error %s", err.Error())
+	o.Color(color.FgRed, "do http request error %s", err.Error())
+}
+
+func (o *Output) Error(err error) {
+	o.Color(color.FgRed, err.Error())
 }
 
 func (o *Output) EchoRequstIng(method string, body []byte) {
-	fmt.Printf("%s ...", method)
-	color.Yellow("\n%s\n\n", body)
+	fmt.Fprintf(o.writer, "%s ...", method)
+	o.Color(color.FgYellow, "\n%s\n\n", body)
+}
+
+func (o *Output) Status(status int) {
+	o.Echo("\nResponse ")
+	if status == 200 {
+		o.Color(color.FgGreen, "Status: %d\n\n", status)
+	} else {
+		o.Color(color.FgRed, "Status: %d\n\n", status)
+	}
+}
+
+// Redirect echo a redirect followed to location
+func (o *Output) Redirect(status int, location string) {
+	o.Color(color.FgYellow, "\nRedirect %d %s", status, location)
+}
+
+// TLS the negotiated version and cipher suite, and a summary of the peer certificates
+func (o *Output) TLS(state *tls.ConnectionState) {
+	o.Color(color.FgCyan, "%s %s", tlsVersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	for _, cert := range state.PeerCertificates {
+		fmt.Fprintf(o.writer, "  %s issued by %s, expires %s", cert.Subject.CommonName, cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))
+		if len(cert.DNSNames) > 0 {
+			fmt.Fprintf(o.writer, ", dns %s", strings.Join(cert.DNSNames, " "))
+		}
+		fmt.Fprintln(o.writer)
+	}
+}
+
+// Timing echo the phases of a request
+func (o *Output) Timing(timing *Timing) {
+	o.Color(color.FgCyan, "\nDNS %s, connect %s, TLS %s, first byte %s, transfer %s, total %s",
+		timing.DNS, timing.Connect, timing.TLS, timing.FirstByte, timing.Transfer, timing.Total)
+}
+
+// Assertion echo the outcome of an expect builtin
+func (o *Output) Assertion(assertion *Assertion) {
+	if assertion.Passed {
+		o.Color(color.FgGreen, "\n%s passed at %s", assertion.Name, assertion.Position)
+	} else {
+		o.Color(color.FgRed, "\n%s", assertion.Message())
+	}
 }
 
 func (o *Output) Finished(count int, names string) {
-	fmt.Println(o.L("="))
-	color.Green("\nFinished. [%d] api requests, [%s] passed", count, names)
-	fmt.Println(o.L("="))
+	fmt.Fprintln(o.writer, o.L("="))
+	o.Color(color.FgGreen, "\nFinished. [%d] api requests, [%s] passed", count, names)
+	fmt.Fprintln(o.writer, o.L("="))
 }
 
 func (o *Output) CopyRight() {
-	fmt.Println(o.L("="))
+	fmt.Fprintln(o.writer, o.L("="))
 	sfs, err := fs.New()
 	if err != nil {
 		panic(err)
@@ -97,26 +230,38 @@ func (o *Output) CopyRight() {
 	if err != nil {
 		panic(err)
 	}
-	color.Yellow(string(DefaultCopyright))
+	o.Color(color.FgYellow, string(DefaultCopyright))
 }
 
 func (o *Output) Headers(headers http.Header) {
 	for key, _ := range headers {
-		fmt.Printf("%s: %s\n", key, headers.Get(key))
+		fmt.Fprintf(o.writer, "%s: %s\n", key, headers.Get(key))
 	}
-	fmt.Println()
+	fmt.Fprintln(o.writer)
 }
 
-func (o *Output) RequestBody(req *http.Request, runner *APIRunner) error {
-	if req.Method != "GET" && req.Method != "DELETE" {
-		body := runner.vm.Lookup(strings.ToLower(req.Method))
-		data, err := prettyjson.Marshal(body)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
+// RequestBody echo the body of req, indented when it is json
+func (o *Output) RequestBody(req *http.Request) {
+	if req.GetBody == nil {
+		return
+	}
+	reader, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	body, err := ioutil.ReadAll(reader)
+	if err != nil || len(body) == 0 {
+		return
+	}
+	indented := new(bytes.Buffer)
+	switch {
+	case strings.Contains(req.Header.Get("Content-Type"), "json") && json.Indent(indented, body, "", "  ") == nil:
+		fmt.Fprintln(o.writer, indented.String())
+	case utf8.Valid(body):
+		fmt.Fprintln(o.writer, string(body))
+	default:
+		fmt.Fprintf(o.writer, "<%d bytes>\n", len(body))
 	}
-	return nil
 }
 
 func (o *Output) ResponseBody(res *http.Response) {
@@ -124,11 +269,11 @@ func (o *Output) ResponseBody(res *http.Response) {
 }
 
 func (o *Output) Echo(s string) {
-	fmt.Printf(s)
+	fmt.Fprint(o.writer, s)
 }
 
 func (o *Output) Echoln(s string) {
-	fmt.Println(s)
+	fmt.Fprintln(o.writer, s)
 }
 
 func (o *Output) Json(obj interface{}) {
@@ -141,7 +286,7 @@ func (o *Output) Json(obj interface{}) {
 		if err != nil {
 			panic(err)
 		}
-		fmt.Print(string(data))
+		fmt.Fprint(o.writer, string(data))
 		break
 	case []byte:
 		var newObj map[string]interface{}
@@ -156,7 +301,7 @@ func (o *Output) Json(obj interface{}) {
 		if err != nil {
 			panic(err)
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(o.writer, string(data))
 		break
 
 	}
@@ -0,0 +1,104 @@
+package pica
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jerloo/funny"
+)
+
+// runParallel run the planned items, at most runner.Parallel of them at the same time.
+// Every item runs in its own vm, starting from a copy of the scope after the init lines
+// and the scopes its dependencies ended with, so only variables declared through
+// `// depends:` are shared. The events of an item are reported at once when it finishes.
+func (runner *APIRunner) runParallel(items []*ApiItem) error {
+	initScope := copyScope(runner.vm.Vars[0])
+	indexes := make(map[string]int)
+	for index, item := range items {
+		indexes[item.Request.Name] = index
+	}
+
+	scopes := make([]funny.Scope, len(items))
+	results := make([]*ApiResult, len(items))
+	errs := make([]error, len(items))
+	done := make([]chan struct{}, len(items))
+	for index := range done {
+		done[index] = make(chan struct{})
+	}
+	workers := make(chan struct{}, runner.Parallel)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for index, item := range items {
+		var deps []int
+		for _, name := range item.Request.Depends {
+			deps = append(deps, indexes[name])
+		}
+		sort.Ints(deps)
+
+		wg.Add(1)
+		go func(index int, item *ApiItem, deps []int) {
+			defer wg.Done()
+			defer close(done[index])
+			for _, dep := range deps {
+				<-done[dep]
+			}
+			for _, dep := range deps {
+				if errs[dep] != nil {
+					errs[index] = fmt.Errorf("skip %s, dependency [%s] failed", item.Request.Name, items[dep].Request.Name)
+					results[index] = &ApiResult{
+						Name:    item.Request.Name,
+						Method:  item.Request.Method,
+						Url:     item.Request.Url,
+						Skipped: true,
+						Error:   errs[index].Error(),
+					}
+					mutex.Lock()
+					runner.Reporter.ItemStart(item)
+					runner.Reporter.ItemEnd(item, results[index])
+					mutex.Unlock()
+					return
+				}
+			}
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			scope := copyScope(initScope)
+			for _, dep := range deps {
+				for k, v := range scopes[dep] {
+					scope[k] = copyValue(v)
+				}
+			}
+			events := &recorder{}
+			child := runner.fork(newFunny(scope), events)
+			errs[index] = child.RunSingle(item)
+			results[index] = child.Results[0]
+			scopes[index] = child.vm.Vars[0]
+
+			mutex.Lock()
+			events.replay(runner.Reporter)
+			mutex.Unlock()
+		}(index, item, deps)
+	}
+	wg.Wait()
+
+	runner.Results = append(runner.Results, results...)
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fork create a runner sharing the configuration of runner but running in its own vm
+func (runner *APIRunner) fork(vm *funny.Funny, reporter Reporter) *APIRunner {
+	child := *runner
+	child.vm = vm
+	child.Reporter = reporter
+	child.Results = nil
+	child.registerFunctions(vm)
+	return &child
+}
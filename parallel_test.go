@@ -0,0 +1,78 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const parallelTestFile = `
+// POST /api/users createUser
+post = {}
+// Response
+assert(status == 200)
+id = json.id
+
+// GET /api/users/<id> getUser
+// depends: createUser
+query = {}
+
+// GET /api/orders listOrders
+query = {}
+
+// GET /api/products listProducts
+query = {}
+
+// GET /api/users/<id>/orders listUserOrders
+// depends: createUser
+query = {}
+`
+
+func TestAPIRunner_RunParallel(t *testing.T) {
+	var running, maxRunning int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "10"}`))
+	}))
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + parallelTestFile))
+	runner.Reporter = NewOutput(false, buf)
+	runner.Parallel = 4
+	err := runner.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(runner.Results))
+	for _, result := range runner.Results {
+		assert.True(t, result.Passed, result.Error)
+	}
+	assert.Equal(t, "createUser", runner.Results[0].Name)
+	assert.True(t, maxRunning > 1)
+	assert.True(t, maxRunning <= 4)
+	assert.Equal(t, 5, strings.Count(buf.String(), "Response "))
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	})
+	runner = NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + parallelTestFile))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	runner.Parallel = 4
+	err = runner.Run()
+	assert.NotNil(t, err)
+	assert.Equal(t, "skip getUser, dependency [createUser] failed", runner.Results[1].Error)
+}
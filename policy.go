@@ -0,0 +1,132 @@
+package pica
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jerloo/funny"
+)
+
+// Policy how an api request is sent
+type Policy struct {
+	// Timeout of one attempt, 0 means no timeout
+	Timeout time.Duration
+	// Retries the max count of retries after the first attempt
+	Retries int
+	// RetryOn status codes, `error` for any transport error or `timeout` for timeouts only
+	RetryOn []string
+	// Backoff the wait before the first retry, doubled for every next one
+	Backoff time.Duration
+	// Delay the wait after the request
+	Delay time.Duration
+}
+
+var (
+	DefaultRetryOn = []string{"error", "502", "503", "504"}
+	DefaultBackoff = 100 * time.Millisecond
+)
+
+// Policy resolve the policy of req. The delay of the runner is overridden by the vm variables
+// `timeout`, `retries`, `retryOn`, `backoff` and `delay`, which are overridden by the
+// annotations of the api like `// retries: 3`. Durations are milliseconds or strings like 2s.
+func (runner *APIRunner) Policy(req *ApiRequest) (*Policy, error) {
+	policy := &Policy{
+		Delay:   time.Duration(runner.Delay) * time.Millisecond,
+		RetryOn: DefaultRetryOn,
+		Backoff: DefaultBackoff,
+	}
+	for _, name := range []string{"timeout", "retries", "retryOn", "backoff", "delay"} {
+		var val funny.Value
+		if annotation, ok := req.Annotations[name]; ok {
+			val = annotation
+		} else {
+			val = runner.vm.LookupDefault(name, nil)
+		}
+		if val == nil {
+			continue
+		}
+		err := policy.set(name, val)
+		if err != nil {
+			return nil, fmt.Errorf("%s of %s %s: %s", name, req.Method, req.Url, err.Error())
+		}
+	}
+	return policy, nil
+}
+
+func (policy *Policy) set(name string, val funny.Value) (err error) {
+	switch name {
+	case "timeout":
+		policy.Timeout, err = durationValue(val)
+	case "backoff":
+		policy.Backoff, err = durationValue(val)
+	case "delay":
+		policy.Delay, err = durationValue(val)
+	case "retries":
+		policy.Retries, err = intValue(val)
+	case "retryOn":
+		policy.RetryOn = nil
+		switch val := val.(type) {
+		case []interface{}:
+			for _, item := range val {
+				policy.RetryOn = append(policy.RetryOn, fmt.Sprint(item))
+			}
+		case string:
+			for _, item := range strings.Split(val, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					policy.RetryOn = append(policy.RetryOn, item)
+				}
+			}
+		default:
+			err = fmt.Errorf("unsupport type [%s], only support [list][string]", funny.Typing(val))
+		}
+	}
+	return
+}
+
+// ShouldRetry whether the result of an attempt should be retried
+func (policy *Policy) ShouldRetry(res *http.Response, err error) bool {
+	for _, item := range policy.RetryOn {
+		if err != nil {
+			if item == "error" {
+				return true
+			}
+			if netErr, ok := err.(net.Error); ok && item == "timeout" && netErr.Timeout() {
+				return true
+			}
+		} else if res != nil && item == strconv.Itoa(res.StatusCode) {
+			return true
+		}
+	}
+	return false
+}
+
+func intValue(val funny.Value) (int, error) {
+	switch val := val.(type) {
+	case int:
+		return val, nil
+	case float64:
+		return int(val), nil
+	case string:
+		return strconv.Atoi(strings.TrimSpace(val))
+	default:
+		return 0, fmt.Errorf("unsupport type [%s], only support [int][string]", funny.Typing(val))
+	}
+}
+
+// durationValue milliseconds for numbers, or strings like 500ms and 2s
+func durationValue(val funny.Value) (time.Duration, error) {
+	if s, ok := val.(string); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil {
+			return d, nil
+		}
+	}
+	ms, err := intValue(val)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,81 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Policy(t *testing.T) {
+	runner := NewAPIRunnerFromContent([]byte(`
+timeout = 3000
+retryOn = [500, 'timeout']
+
+// GET /api/users listUsers
+// retries: 2
+// Note: the users of the first page
+// backoff: 1s
+`))
+	runner.Delay = 20
+	runner.Parse()
+	runner.ParseAPIItems()
+	runner.RunInitLines()
+
+	policy, err := runner.Policy(runner.APIItems[0].Request)
+	assert.Nil(t, err)
+	assert.Equal(t, &Policy{
+		Timeout: 3 * time.Second,
+		Retries: 2,
+		RetryOn: []string{"500", "timeout"},
+		Backoff: time.Second,
+		Delay:   20 * time.Millisecond,
+	}, policy)
+	assert.Equal(t, map[string]string{"retries": "2", "backoff": "1s"}, runner.APIItems[0].Request.Annotations)
+}
+
+func TestAPIRunner_Retry(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		if r.URL.Path == "/api/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+backoff = 1
+
+// GET /api/users listUsers
+// retries: 3
+query = {}
+// Response
+assert(status == 200)
+
+// GET /api/slow slow
+// timeout: 20ms
+// retries: 1
+// retryOn: timeout
+query = {}
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, len(runner.Results[0].Attempts))
+	assert.Equal(t, 503, runner.Results[0].Attempts[0].Status)
+	assert.Equal(t, 200, runner.Results[0].Attempts[2].Status)
+	assert.True(t, runner.Results[0].Passed)
+	assert.Equal(t, 2, len(runner.Results[1].Attempts))
+	assert.False(t, runner.Results[1].Passed)
+}
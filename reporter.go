@@ -0,0 +1,264 @@
+package pica
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Reporter receives the lifecycle events of a run. Runs nest, a task is a run of
+// the runs of its files, so reporters writing whole documents like JUnit write them
+// when the outermost run ends.
+type Reporter interface {
+	// RunStart before the items of a file or a task, items are nil for tasks
+	RunStart(name string, items []*ApiItem)
+	// ItemStart before the request lines of an item
+	ItemStart(item *ApiItem)
+	// RequestSent for every attempt of a request, counted from 1
+	RequestSent(req *ApiRequest, httpReq *http.Request, attempt int)
+	// ResponseReceived for every attempt, the body is read already and err is the error of the attempt
+	ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error)
+	// AssertionResult for every assertion of the response block
+	AssertionResult(item *ApiItem, assertion *Assertion)
+	// ItemEnd after the response block, or after the item is skipped
+	ItemEnd(item *ApiItem, result *ApiResult)
+	// RunEnd after the last item, with the error ending the run
+	RunEnd(results []*ApiResult, err error)
+}
+
+// Reporters create the reporters selectable by `--reporter name[=file]`, more can be registered from Go
+var Reporters = map[string]func(w io.Writer, debug bool) Reporter{
+	"pretty": func(w io.Writer, debug bool) Reporter { return NewOutput(debug, w) },
+	"dots":   func(w io.Writer, debug bool) Reporter { return NewDotsReporter(w, false) },
+	"quiet":  func(w io.Writer, debug bool) Reporter { return NewDotsReporter(w, true) },
+	"jsonl":  func(w io.Writer, debug bool) Reporter { return NewJSONLinesReporter(w) },
+	"junit":  func(w io.Writer, debug bool) Reporter { return NewJUnitReporter(w) },
+	"tap":    func(w io.Writer, debug bool) Reporter { return NewTAPReporter(w) },
+	"html":   func(w io.Writer, debug bool) Reporter { return NewHTMLReporter(w) },
+	"har":    func(w io.Writer, debug bool) Reporter { return NewHARReporter(w) },
+}
+
+// ParseReporters create the reporters of specs like `pretty`, `junit=report.xml` or `tap=-`,
+// writing to stdout without a file or with `-`. The files are closed by the returned func.
+func ParseReporters(specs []string, debug bool) (Reporter, func() error, error) {
+	var reporters MultiReporter
+	var files []*os.File
+	closeFiles := func() error {
+		var err error
+		for _, file := range files {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}
+	for _, spec := range specs {
+		name, path := spec, ""
+		if index := strings.Index(spec, "="); index >= 0 {
+			name, path = spec[:index], spec[index+1:]
+		}
+		create, ok := Reporters[name]
+		if !ok {
+			closeFiles()
+			return nil, nil, fmt.Errorf("unsupport reporter [%s], only support %s", name, reporterNames())
+		}
+		var w io.Writer = os.Stdout
+		if path != "" && path != "-" {
+			file, err := os.Create(path)
+			if err != nil {
+				closeFiles()
+				return nil, nil, err
+			}
+			files = append(files, file)
+			w = file
+		}
+		reporters = append(reporters, create(w, debug))
+	}
+	if len(reporters) == 1 {
+		return reporters[0], closeFiles, nil
+	}
+	return reporters, closeFiles, nil
+}
+
+func reporterNames() string {
+	var names []string
+	for name := range Reporters {
+		names = append(names, "["+name+"]")
+	}
+	sort.Strings(names)
+	return strings.Join(names, "")
+}
+
+// MultiReporter sends every event to all of its reporters in order
+type MultiReporter []Reporter
+
+func (m MultiReporter) RunStart(name string, items []*ApiItem) {
+	for _, r := range m {
+		r.RunStart(name, items)
+	}
+}
+
+func (m MultiReporter) ItemStart(item *ApiItem) {
+	for _, r := range m {
+		r.ItemStart(item)
+	}
+}
+
+func (m MultiReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	for _, r := range m {
+		r.RequestSent(req, httpReq, attempt)
+	}
+}
+
+func (m MultiReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+	for _, r := range m {
+		r.ResponseReceived(req, res, body, err)
+	}
+}
+
+func (m MultiReporter) AssertionResult(item *ApiItem, assertion *Assertion) {
+	for _, r := range m {
+		r.AssertionResult(item, assertion)
+	}
+}
+
+func (m MultiReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	for _, r := range m {
+		r.ItemEnd(item, result)
+	}
+}
+
+func (m MultiReporter) RunEnd(results []*ApiResult, err error) {
+	for _, r := range m {
+		r.RunEnd(results, err)
+	}
+}
+
+// recorder keeps the events of an item running in parallel, replayed at once when it finishes
+type recorder struct {
+	events []func(Reporter)
+}
+
+func (r *recorder) RunStart(name string, items []*ApiItem) {
+	r.events = append(r.events, func(to Reporter) { to.RunStart(name, items) })
+}
+
+func (r *recorder) ItemStart(item *ApiItem) {
+	r.events = append(r.events, func(to Reporter) { to.ItemStart(item) })
+}
+
+func (r *recorder) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {
+	r.events = append(r.events, func(to Reporter) { to.RequestSent(req, httpReq, attempt) })
+}
+
+func (r *recorder) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+	r.events = append(r.events, func(to Reporter) { to.ResponseReceived(req, res, body, err) })
+}
+
+func (r *recorder) AssertionResult(item *ApiItem, assertion *Assertion) {
+	r.events = append(r.events, func(to Reporter) { to.AssertionResult(item, assertion) })
+}
+
+func (r *recorder) ItemEnd(item *ApiItem, result *ApiResult) {
+	r.events = append(r.events, func(to Reporter) { to.ItemEnd(item, result) })
+}
+
+func (r *recorder) RunEnd(results []*ApiResult, err error) {
+	r.events = append(r.events, func(to Reporter) { to.RunEnd(results, err) })
+}
+
+func (r *recorder) replay(to Reporter) {
+	for _, event := range r.events {
+		event(to)
+	}
+}
+
+// runDepth tracks the nesting of runs, for reporters writing at the end of the outermost one
+type runDepth int
+
+func (d *runDepth) start() bool {
+	*d++
+	return *d == 1
+}
+
+func (d *runDepth) end() bool {
+	*d--
+	return *d == 0
+}
+
+// DotsReporter prints a dot for every passed item and F for every failed one,
+// then the failures and a summary. Quiet prints the failures and the summary only.
+type DotsReporter struct {
+	Quiet bool
+
+	writer   io.Writer
+	runs     runDepth
+	passed   int
+	failures []string
+}
+
+func NewDotsReporter(w io.Writer, quiet bool) *DotsReporter {
+	return &DotsReporter{Quiet: quiet, writer: w}
+}
+
+func (d *DotsReporter) RunStart(name string, items []*ApiItem) {
+	if d.runs.start() {
+		d.passed = 0
+		d.failures = nil
+	}
+}
+
+func (d *DotsReporter) ItemStart(item *ApiItem) {}
+
+func (d *DotsReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {}
+
+func (d *DotsReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+}
+
+func (d *DotsReporter) AssertionResult(item *ApiItem, assertion *Assertion) {}
+
+func (d *DotsReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	mark := "."
+	if result.Passed {
+		d.passed++
+	} else {
+		mark = "F"
+		d.failures = append(d.failures, fmt.Sprintf("%s %s %s\n%s", result.Method, result.Url, result.Name, result.Error))
+	}
+	if !d.Quiet {
+		fmt.Fprint(d.writer, mark)
+	}
+}
+
+func (d *DotsReporter) RunEnd(results []*ApiResult, err error) {
+	if !d.runs.end() {
+		return
+	}
+	if !d.Quiet {
+		fmt.Fprintln(d.writer)
+	}
+	if err != nil && len(d.failures) == 0 {
+		d.failures = append(d.failures, err.Error())
+	}
+	for index, failure := range d.failures {
+		fmt.Fprintf(d.writer, "\n%d) %s\n", index+1, failure)
+	}
+	summary := color.New(color.FgGreen)
+	if len(d.failures) > 0 {
+		summary = color.New(color.FgRed)
+	}
+	if !colored(d.writer) {
+		summary.DisableColor()
+	}
+	summary.Fprintf(d.writer, "\n%d passed, %d failed\n", d.passed, len(d.failures))
+}
+
+// colored whether colors are written to w, only stdout and stderr are colored
+func colored(w io.Writer) bool {
+	return (w == os.Stdout || w == os.Stderr) && !color.NoColor
+}
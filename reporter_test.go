@@ -0,0 +1,139 @@
+package pica
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const reporterTestFile = `
+// GET /users listUsers
+// Response
+expectEqual(status, 200)
+
+// GET /missing getMissing
+// Response
+expectEqual(status, 200)
+`
+
+func newReporterTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+}
+
+func TestReporters(t *testing.T) {
+	server := newReporterTestServer()
+	defer server.Close()
+
+	pretty, dots, jsonl, junit, tap := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + reporterTestFile))
+	runner.Reporter = MultiReporter{
+		NewOutput(false, pretty),
+		NewDotsReporter(dots, false),
+		NewJSONLinesReporter(jsonl),
+		NewJUnitReporter(junit),
+		NewTAPReporter(tap),
+	}
+	assert.NotNil(t, runner.Run())
+
+	assert.Contains(t, pretty.String(), "GET /users listUsers")
+	assert.Contains(t, pretty.String(), "Finished. [2] api requests, [1] passed, [1] failed")
+
+	assert.True(t, strings.HasPrefix(dots.String(), ".F\n"))
+	assert.Contains(t, dots.String(), "1) GET /missing getMissing")
+	assert.True(t, strings.HasSuffix(dots.String(), "1 passed, 1 failed\n"))
+
+	var events []string
+	scanner := bufio.NewScanner(jsonl)
+	for scanner.Scan() {
+		event := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event["event"].(string))
+	}
+	assert.Equal(t, []string{
+		"runStart",
+		"itemStart", "requestSent", "responseReceived", "assertion", "itemEnd",
+		"itemStart", "requestSent", "responseReceived", "assertion", "itemEnd",
+		"runEnd",
+	}, events)
+
+	doc := &junitSuites{}
+	assert.Nil(t, xml.Unmarshal(junit.Bytes(), doc))
+	assert.Equal(t, 2, doc.Tests)
+	assert.Equal(t, 1, doc.Failures)
+	assert.Equal(t, "pica", doc.Suites[0].Name)
+	assert.Nil(t, doc.Suites[0].Cases[0].Failure)
+	assert.Contains(t, doc.Suites[0].Cases[1].Failure.Text, "expectEqual failed")
+
+	assert.Equal(t, strings.Join([]string{
+		"TAP version 13",
+		"ok 1 - listUsers GET /users",
+		"not ok 2 - getMissing GET /missing",
+		"  ---",
+		"  status: 404",
+		"  message: |",
+	}, "\n"), strings.Join(strings.Split(tap.String(), "\n")[:6], "\n"))
+	assert.True(t, strings.HasSuffix(tap.String(), "  ...\n1..2\n"))
+}
+
+func TestReporters_Parallel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer server.Close()
+
+	tap := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + parallelTestFile))
+	runner.Reporter = NewTAPReporter(tap)
+	runner.Parallel = 4
+	assert.NotNil(t, runner.Run())
+	// items are reported in the order they finish
+	assert.Regexp(t, `not ok \d - createUser POST /api/users\n`, tap.String())
+	assert.Contains(t, tap.String(), "# SKIP skip getUser, dependency [createUser] failed")
+	assert.True(t, strings.HasSuffix(tap.String(), "1..5\n"))
+}
+
+func TestParseReporters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pica-reporters")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	server := newReporterTestServer()
+	defer server.Close()
+
+	report := filepath.Join(dir, "report.xml")
+	reporter, closeReporters, err := ParseReporters([]string{"quiet=" + filepath.Join(dir, "quiet.txt"), "junit=" + report}, false)
+	assert.Nil(t, err)
+	runner := NewAPIRunnerFromContent([]byte("baseUrl = '" + server.URL + "'\n" + reporterTestFile))
+	runner.Reporter = reporter
+	runner.APINames = []string{"listUsers"}
+	assert.Nil(t, runner.Run())
+	assert.Nil(t, closeReporters())
+
+	data, err := ioutil.ReadFile(report)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+	assert.Contains(t, string(data), `<testcase name="listUsers" classname="pica"`)
+	data, err = ioutil.ReadFile(filepath.Join(dir, "quiet.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "\n1 passed, 0 failed\n", string(data))
+
+	_, _, err = ParseReporters([]string{"xml"}, false)
+	assert.NotNil(t, err)
+}
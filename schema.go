@@ -0,0 +1,175 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/jerloo/funny"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	ResponseFunctions["assertSchema"] = true
+}
+
+// AssertSchema validate the json response against a json schema
+//
+//	assertSchema('schemas/user.json')
+//	assertSchema('openapi.yaml#/components/schemas/User')
+//	assertSchema({
+//	    type = 'object'
+//	    required = ['id']
+//	}, json.items)
+//
+// the schema is a file path, relative to the pica file, optionally with a
+// json pointer fragment, or an inline map. Each violation is recorded as a
+// failed assertion with the json pointer of the value violating it.
+func (runner *APIRunner) AssertSchema(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if runner.item == nil || runner.result == nil {
+		panic("assertSchema must be called in the response block of an api")
+	}
+	if len(args) < 1 || len(args) > 2 {
+		panic(fmt.Sprintf("assertSchema requires 1 or 2 arguments but got %d", len(args)))
+	}
+	pos := interpreter.Current
+	if call := findCall(runner.statement, "assertSchema"); call != nil {
+		pos = call.Position
+	}
+	var doc funny.Value
+	if len(args) == 2 {
+		doc = args[1]
+	} else {
+		doc = interpreter.LookupDefault("json", nil)
+	}
+
+	schema, err := runner.compileSchema(args[0])
+	if err != nil {
+		panic(fmt.Sprintf("assertSchema %s", err.Error()))
+	}
+	instance, err := jsonInstance(doc)
+	if err != nil {
+		panic(fmt.Sprintf("assertSchema %s", err.Error()))
+	}
+
+	var assertions []*Assertion
+	err = schema.Validate(instance)
+	if err == nil {
+		assertions = append(assertions, &Assertion{
+			Name:     "assertSchema",
+			Passed:   true,
+			Expected: schema.Location,
+			Actual:   "valid",
+			Position: positionString(pos),
+		})
+	} else if ve, ok := err.(*jsonschema.ValidationError); ok {
+		for _, violation := range violations(ve) {
+			pointer := violation.InstanceLocation
+			if pointer == "" {
+				pointer = "/"
+			}
+			assertions = append(assertions, &Assertion{
+				Name:     "assertSchema",
+				Expected: violation.AbsoluteKeywordLocation,
+				Actual:   fmt.Sprintf("%s %s", pointer, violation.Message),
+				Position: positionString(pos),
+			})
+		}
+	} else {
+		panic(fmt.Sprintf("assertSchema %s", err.Error()))
+	}
+	for _, assertion := range assertions {
+		runner.result.Assertions = append(runner.result.Assertions, assertion)
+		runner.Reporter.AssertionResult(runner.item, assertion)
+	}
+	return funny.Value(err == nil)
+}
+
+// violations the leaf errors of a validation, the errors having causes
+// like `$ref` or `allOf` only repeat what their causes tell
+func violations(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range ve.Causes {
+		leaves = append(leaves, violations(cause)...)
+	}
+	return leaves
+}
+
+// compileSchema compile the schema of a file path or an inline map
+func (runner *APIRunner) compileSchema(val funny.Value) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = loadSchemaURL
+	switch val := val.(type) {
+	case string:
+		return compiler.Compile(runner.schemaURL(val))
+	case map[string]funny.Value:
+		data, err := json.Marshal(normalize(val))
+		if err != nil {
+			return nil, err
+		}
+		if err := compiler.AddResource("inline.json", bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+		return compiler.Compile("inline.json")
+	default:
+		return nil, fmt.Errorf("schema must be a file path or a map, but got %s", typeName(val))
+	}
+}
+
+// schemaURL the file url of a schema path, relative paths start from the pica file
+func (runner *APIRunner) schemaURL(path string) string {
+	fragment := ""
+	if i := strings.Index(path, "#"); i >= 0 {
+		path, fragment = path[:i], path[i:]
+	}
+	abs, err := filepath.Abs(runner.resolvePath(path))
+	if err != nil {
+		abs = path
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+	return u.String() + fragment
+}
+
+// loadSchemaURL load schema documents, converting yaml ones like openapi documents to json
+func loadSchemaURL(s string) (io.ReadCloser, error) {
+	r, err := jsonschema.LoadURL(s)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(strings.SplitN(s, "#", 2)[0]))
+	if ext != ".yaml" && ext != ".yml" {
+		return r, nil
+	}
+	defer r.Close()
+	var doc interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode %s: %s", s, err.Error())
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// jsonInstance convert vm values to the plain json values the validator expects
+func jsonInstance(val funny.Value) (interface{}, error) {
+	data, err := json.Marshal(normalize(val))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var instance interface{}
+	err = decoder.Decode(&instance)
+	return instance, err
+}
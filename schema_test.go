@@ -0,0 +1,98 @@
+package pica
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_AssertSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 10, "name": "pica", "tags": ["a", 2]}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica-schema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "schemas"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "schemas", "user.json"), []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["id", "name"],
+  "properties": {
+    "id": {"type": "integer"},
+    "name": {"type": "string"},
+    "tags": {"type": "array", "items": {"type": "string"}}
+  }
+}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(`
+openapi: 3.0.0
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, email]
+      properties:
+        id:
+          $ref: '#/components/schemas/Id'
+    Id:
+      type: string
+`), 0644))
+	filename := filepath.Join(dir, "pica.fun")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+
+// GET /api/users/1 getUser
+query = {}
+// Response
+assertSchema('schemas/user.json')
+assertSchema({
+    type = 'object'
+    required = ['id']
+})
+
+// GET /api/users/2 getOpenAPIUser
+query = {}
+// Response
+assertSchema('openapi.yaml#/components/schemas/User')
+`), 0644))
+
+	runner := NewAPIRunnerFromFile(filename, []string{}, 0)
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err = runner.Run()
+	assert.NotNil(t, err)
+
+	first := runner.Results[0]
+	assert.False(t, first.Passed)
+	var actuals []string
+	for _, a := range first.Assertions {
+		if !a.Passed {
+			actuals = append(actuals, a.Actual)
+		}
+	}
+	assert.Equal(t, []string{"/tags/1 expected string, but got number"}, actuals)
+	assert.True(t, first.Assertions[len(first.Assertions)-1].Passed)
+	assert.Contains(t, first.Error, "1 expectations failed")
+
+	runner = NewAPIRunnerFromFile(filename, []string{"getOpenAPIUser"}, 0)
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.NotNil(t, runner.Run())
+	second := runner.Results[0]
+	assert.False(t, second.Passed)
+	actuals = nil
+	for _, a := range second.Assertions {
+		actuals = append(actuals, a.Actual)
+	}
+	assert.ElementsMatch(t, []string{
+		"/ missing properties: 'email'",
+		"/id expected string, but got number",
+	}, actuals)
+}
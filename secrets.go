@@ -0,0 +1,64 @@
+package pica
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SecretHeaders the headers masked in report files, keeping the auth scheme and the cookie names
+// like `Bearer ***`. Headers of custom signers can be added from Go.
+var SecretHeaders = map[string]bool{
+	"Authorization":        true,
+	"Proxy-Authorization":  true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+	"X-Signature":          true,
+	"X-Amz-Security-Token": true,
+	"X-Api-Key":            true,
+}
+
+// ShowSecrets writes the secret headers into report files as they are, set by `--show-secrets`
+var ShowSecrets bool
+
+const secretMask = "***"
+
+// maskHeader the value of the header name, masked when it is secret
+func maskHeader(name, value string) string {
+	name = http.CanonicalHeaderKey(name)
+	if ShowSecrets || !SecretHeaders[name] {
+		return value
+	}
+	switch name {
+	case "Cookie":
+		pairs := strings.Split(value, ";")
+		for i, pair := range pairs {
+			pairs[i] = maskCookie(pair)
+		}
+		return strings.Join(pairs, ";")
+	case "Set-Cookie":
+		// the attributes after the cookie are kept
+		parts := strings.SplitN(value, ";", 2)
+		parts[0] = maskCookie(parts[0])
+		return strings.Join(parts, ";")
+	}
+	if index := strings.Index(value, " "); index > 0 {
+		return value[:index+1] + secretMask
+	}
+	return secretMask
+}
+
+// maskCookie a `name=value` pair with the value masked
+func maskCookie(pair string) string {
+	if index := strings.Index(pair, "="); index >= 0 {
+		return pair[:index+1] + secretMask
+	}
+	return secretMask
+}
+
+// maskSecret the value of a cookie masked, like the cookie headers
+func maskSecret(value string) string {
+	if ShowSecrets {
+		return value
+	}
+	return secretMask
+}
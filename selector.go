@@ -0,0 +1,78 @@
+package pica
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Selector filters api items by name, method, path and tags, an empty field matches everything.
+// Names and Skip are exact names, globs like user* or regular expressions like user.*
+type Selector struct {
+	Names    []string
+	Skip     []string
+	Method   string
+	PathGlob string
+	Tags     []string
+}
+
+// Match whether the request is selected
+func (s *Selector) Match(req *ApiRequest) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.Names) > 0 && !matchAnyName(s.Names, req.Name) {
+		return false
+	}
+	if matchAnyName(s.Skip, req.Name) {
+		return false
+	}
+	if s.Method != "" && !strings.EqualFold(s.Method, req.Method) {
+		return false
+	}
+	if s.PathGlob != "" {
+		matched, err := path.Match(s.PathGlob, req.Url)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	for _, tag := range s.Tags {
+		if !hasTag(req, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTag(req *ApiRequest, tag string) bool {
+	tag = strings.TrimPrefix(tag, "@")
+	for _, item := range req.Tags {
+		if item == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyName(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchName(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchName(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	if matched, err := path.Match(pattern, name); err == nil && matched {
+		return true
+	}
+	reg, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false
+	}
+	return reg.MatchString(name)
+}
@@ -0,0 +1,33 @@
+package pica
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector_Match(t *testing.T) {
+	listUsers := &ApiRequest{Method: "GET", Url: "/api/users", Name: "listUsers", Tags: []string{"smoke", "read"}}
+	getUser := &ApiRequest{Method: "GET", Url: "/api/users/<id>", Name: "getUser", Tags: []string{"read"}}
+	deleteUser := &ApiRequest{Method: "DELETE", Url: "/api/users/<id>", Name: "deleteUser"}
+
+	cases := []struct {
+		selector *Selector
+		expected []bool
+	}{
+		{nil, []bool{true, true, true}},
+		{&Selector{Names: []string{"getUser"}}, []bool{false, true, false}},
+		{&Selector{Names: []string{"*User"}}, []bool{false, true, true}},
+		{&Selector{Names: []string{"list.*"}}, []bool{true, false, false}},
+		{&Selector{Skip: []string{"delete*"}}, []bool{true, true, false}},
+		{&Selector{Tags: []string{"@smoke"}}, []bool{true, false, false}},
+		{&Selector{Method: "get", PathGlob: "/api/users/*"}, []bool{false, true, false}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, []bool{
+			c.selector.Match(listUsers),
+			c.selector.Match(getUser),
+			c.selector.Match(deleteUser),
+		}, "%+v", c.selector)
+	}
+}
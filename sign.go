@@ -0,0 +1,301 @@
+package pica
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jerloo/funny"
+)
+
+// Signer signs the final http request of an api, after its headers, body and auth are set
+type Signer interface {
+	Sign(req *http.Request, body []byte) error
+}
+
+// Signers create the signer of a `sign` block by its type, more can be registered from Go
+var Signers = map[string]func(block map[string]funny.Value) (Signer, error){
+	"aws":  newAWSSigner,
+	"hmac": newHMACSigner,
+}
+
+// signRequest sign req by the `sign` block of the vm, absent or of type none signs nothing
+//
+//	sign = {
+//	    type = 'aws'
+//	    accessKey = env('AWS_ACCESS_KEY_ID')
+//	    secretKey = env('AWS_SECRET_ACCESS_KEY')
+//	    region = 'us-east-1'
+//	    service = 's3'
+//	}
+func (runner *APIRunner) signRequest(req *http.Request) error {
+	block, ok := runner.vm.LookupDefault("sign", nil).(map[string]funny.Value)
+	if !ok || len(block) == 0 {
+		return nil
+	}
+	signType := strings.ToLower(authString(block, "type"))
+	if signType == "none" {
+		return nil
+	}
+	newSigner, ok := Signers[signType]
+	if !ok {
+		return fmt.Errorf("sign unsupport type [%s]", signType)
+	}
+	signer, err := newSigner(block)
+	if err != nil {
+		return fmt.Errorf("sign %s %s", signType, err.Error())
+	}
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		body, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+	}
+	return signer.Sign(req, body)
+}
+
+// AWSSigner signs requests by AWS Signature Version 4
+type AWSSigner struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Region       string
+	Service      string
+
+	now func() time.Time
+}
+
+func newAWSSigner(block map[string]funny.Value) (Signer, error) {
+	signer := &AWSSigner{
+		AccessKey:    authString(block, "accessKey"),
+		SecretKey:    authString(block, "secretKey"),
+		SessionToken: authString(block, "sessionToken"),
+		Region:       authString(block, "region"),
+		Service:      authString(block, "service"),
+		now:          time.Now,
+	}
+	if signer.AccessKey == "" || signer.SecretKey == "" || signer.Region == "" || signer.Service == "" {
+		return nil, fmt.Errorf("requires accessKey, secretKey, region and service")
+	}
+	return signer, nil
+}
+
+// Sign set the X-Amz-Date and Authorization headers of req
+func (s *AWSSigner) Sign(req *http.Request, body []byte) error {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	bodyHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+	if s.Service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", bodyHash)
+	}
+
+	// host, content-type and x-amz-* headers are signed
+	signed := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		signed["host"] = req.Host
+	}
+	for key, values := range req.Header {
+		name := strings.ToLower(key)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			trimmed := make([]string, len(values))
+			for i, value := range values {
+				trimmed[i] = strings.Join(strings.Fields(value), " ")
+			}
+			signed[name] = strings.Join(trimmed, ",")
+		}
+	}
+	names := make([]string, 0, len(signed))
+	for name := range signed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + signed[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		bodyHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.Region, s.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	key := []byte("AWS4" + s.SecretKey)
+	for _, part := range []string{date, s.Region, s.Service, "aws4_request"} {
+		key = hmacSum(sha256.New, key, []byte(part))
+	}
+	signature := hex.EncodeToString(hmacSum(sha256.New, key, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+	return nil
+}
+
+// HMACSigner signs requests by a hmac of some fields of the request joined by Separator.
+// Fields are method, path, query (sorted), bodyHash (hex sha256), timestamp (unix seconds) and keyId.
+type HMACSigner struct {
+	Key       string
+	KeyId     string
+	Algorithm string
+	Encoding  string
+	Fields    []string
+	Separator string
+
+	Header          string
+	TimestampHeader string
+	KeyIdHeader     string
+
+	now func() time.Time
+}
+
+func newHMACSigner(block map[string]funny.Value) (Signer, error) {
+	signer := &HMACSigner{
+		Key:             authString(block, "key"),
+		KeyId:           authString(block, "keyId"),
+		Algorithm:       "sha256",
+		Encoding:        "hex",
+		Fields:          []string{"method", "path", "query", "bodyHash", "timestamp"},
+		Separator:       "\n",
+		Header:          "X-Signature",
+		TimestampHeader: "X-Timestamp",
+		KeyIdHeader:     "X-Key-Id",
+		now:             time.Now,
+	}
+	if signer.Key == "" {
+		return nil, fmt.Errorf("requires key")
+	}
+	for name, field := range map[string]*string{
+		"algorithm":       &signer.Algorithm,
+		"encoding":        &signer.Encoding,
+		"separator":       &signer.Separator,
+		"header":          &signer.Header,
+		"timestampHeader": &signer.TimestampHeader,
+		"keyIdHeader":     &signer.KeyIdHeader,
+	} {
+		if val, ok := block[name]; ok {
+			*field = fmt.Sprint(val)
+		}
+	}
+	if fields, ok := block["fields"]; ok {
+		signer.Fields = stringList(fields)
+	}
+	if signer.hash() == nil {
+		return nil, fmt.Errorf("unsupport algorithm [%s], only support [sha1][sha256][sha512]", signer.Algorithm)
+	}
+	if signer.Encoding != "hex" && signer.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupport encoding [%s], only support [hex][base64]", signer.Encoding)
+	}
+	return signer, nil
+}
+
+func (s *HMACSigner) hash() func() hash.Hash {
+	switch strings.ToLower(s.Algorithm) {
+	case "sha1":
+		return sha1.New
+	case "sha256":
+		return sha256.New
+	case "sha512":
+		return sha512.New
+	}
+	return nil
+}
+
+// Sign set the signature, timestamp and key id headers of req
+func (s *HMACSigner) Sign(req *http.Request, body []byte) error {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	values := map[string]string{
+		"method":    req.Method,
+		"path":      req.URL.EscapedPath(),
+		"query":     canonicalQuery(req.URL.Query()),
+		"bodyHash":  sha256Hex(body),
+		"timestamp": timestamp,
+		"keyId":     s.KeyId,
+	}
+	parts := make([]string, len(s.Fields))
+	for i, field := range s.Fields {
+		val, ok := values[field]
+		if !ok {
+			return fmt.Errorf("sign hmac unsupport field [%s]", field)
+		}
+		parts[i] = val
+	}
+	sum := hmacSum(s.hash(), []byte(s.Key), []byte(strings.Join(parts, s.Separator)))
+	signature := hex.EncodeToString(sum)
+	if s.Encoding == "base64" {
+		signature = base64.StdEncoding.EncodeToString(sum)
+	}
+	req.Header.Set(s.Header, signature)
+	if s.TimestampHeader != "" {
+		req.Header.Set(s.TimestampHeader, timestamp)
+	}
+	if s.KeyId != "" && s.KeyIdHeader != "" {
+		req.Header.Set(s.KeyIdHeader, s.KeyId)
+	}
+	return nil
+}
+
+// canonicalQuery the query sorted by keys and values, escaped by RFC 3986
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEscape(key)+"="+uriEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func uriEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSum(h func() hash.Hash, key, data []byte) []byte {
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,72 @@
+package pica
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the get-vanilla case of the aws signature v4 test suite
+func TestAWSSigner_Sign(t *testing.T) {
+	signer := &AWSSigner{
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:    "us-east-1",
+		Service:   "service",
+		now: func() time.Time {
+			return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+		},
+	}
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, signer.Sign(req, nil))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestAPIRunner_Sign(t *testing.T) {
+	var signatures []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodyHash := sha256.Sum256(body)
+		message := strings.Join([]string{
+			r.Method, r.URL.Path, "a=1&b=2", hex.EncodeToString(bodyHash[:]), r.Header.Get("X-Timestamp"),
+		}, "\n")
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(message))
+		expected := hex.EncodeToString(mac.Sum(nil))
+		signatures = append(signatures, r.Header.Get("X-Key-Id") == "client" && r.Header.Get("X-Signature") == expected)
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+sign = {
+    type = 'hmac'
+    key = 'secret'
+    keyId = 'client'
+}
+
+// POST /api/orders?b=2&a=1 createOrder
+post = {
+    id = 1
+}
+
+// GET /api/orders/1?a=1&b=2 getOrder
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	assert.Equal(t, []bool{true, true}, signatures)
+}
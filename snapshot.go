@@ -0,0 +1,275 @@
+package pica
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jerloo/funny"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// SnapshotsDir the directory of snapshots, next to the pica file
+const SnapshotsDir = "__snapshots__"
+
+// ignoredValue replaces the volatile values in snapshots
+const ignoredValue = "<ignored>"
+
+func init() {
+	ResponseFunctions["snapshot"] = true
+}
+
+// ResponseSnapshot the normalised response of an api stored in the snapshots directory
+type ResponseSnapshot struct {
+	Status int         `json:"status"`
+	Body   interface{} `json:"body"`
+}
+
+// Snapshot compare the response of the api with its snapshot
+//
+//	snapshot()
+//	snapshot(['/id', '/items/*/createdAt', '^\d{4}-\d{2}-\d{2}T'])
+//
+// the snapshot is written when missing or when the runner updates snapshots.
+// Ignore rules starting with / are json pointers of the body, `*` matching any
+// key or index, the others are regular expressions of string values.
+func (runner *APIRunner) Snapshot(interpreter *funny.Funny, args []funny.Value) funny.Value {
+	if runner.item == nil || runner.result == nil {
+		panic("snapshot must be called in the response block of an api")
+	}
+	pos := interpreter.Current
+	if call := findCall(runner.statement, "snapshot"); call != nil {
+		pos = call.Position
+	}
+	var rules []string
+	for _, arg := range args {
+		rules = append(rules, stringList(arg)...)
+	}
+	assertion, err := runner.snapshot(runner.item, rules)
+	if err != nil {
+		panic(fmt.Sprintf("snapshot %s", err.Error()))
+	}
+	assertion.Position = positionString(pos)
+	runner.result.Assertions = append(runner.result.Assertions, assertion)
+	runner.Reporter.AssertionResult(runner.item, assertion)
+	return funny.Value(assertion.Passed)
+}
+
+// snapshot compare the response of item with its snapshot, writing it when
+// missing or updating. The ignore rules are added to those of the vm variable
+// and the annotation `snapshotIgnore`.
+func (runner *APIRunner) snapshot(item *ApiItem, rules []string) (*Assertion, error) {
+	rules = append(rules, stringList(runner.vm.LookupDefault("snapshotIgnore", nil))...)
+	if annotation, ok := item.Request.Annotations["snapshotIgnore"]; ok {
+		rules = append(rules, stringList(annotation)...)
+	}
+	ignore, err := newSnapshotIgnore(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &ResponseSnapshot{
+		Status: item.Response.Status,
+		Body:   ignore.apply("", responseDocument(item.Response)),
+	}
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return nil, err
+	}
+	actual := buf.Bytes()
+
+	filename := runner.snapshotFile(item.Request)
+	assertion := &Assertion{
+		Name:     "snapshot",
+		Passed:   true,
+		Expected: filename,
+	}
+	expected, err := ioutil.ReadFile(filename)
+	switch {
+	case os.IsNotExist(err) || (err == nil && runner.UpdateSnapshots && string(expected) != string(actual)):
+		if err == nil {
+			assertion.Actual = "updated"
+		} else {
+			assertion.Actual = "written"
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(filename, actual, 0644); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case string(expected) == string(actual):
+		assertion.Actual = "matched"
+	default:
+		assertion.Passed = false
+		assertion.Actual = "changed, run with --update-snapshots to accept\n" + diffLines(string(expected), string(actual))
+	}
+	return assertion, nil
+}
+
+// snapshotFile the snapshot of req like __snapshots__/pica/getUser.json
+func (runner *APIRunner) snapshotFile(req *ApiRequest) string {
+	dir, base := ".", "pica"
+	if runner.Filename != "" {
+		dir = filepath.Dir(runner.Filename)
+		base = strings.TrimSuffix(filepath.Base(runner.Filename), filepath.Ext(runner.Filename))
+	}
+	name := req.Name
+	if name == "" {
+		name = req.Method + " " + req.Url
+	}
+	name = regexp.MustCompile(`[^\w.-]+`).ReplaceAllString(name, "_")
+	return filepath.Join(dir, SnapshotsDir, base, name+".json")
+}
+
+// responseDocument the decoded body or the text of the response
+func responseDocument(res *ApiResponse) interface{} {
+	_, doc, err := decodeResponse(res)
+	if err != nil || doc == nil {
+		return string(res.Body)
+	}
+	return normalize(doc)
+}
+
+// snapshotIgnore the json pointers and value patterns ignored by snapshots
+type snapshotIgnore struct {
+	pointers [][]string
+	patterns []*regexp.Regexp
+}
+
+func newSnapshotIgnore(rules []string) (*snapshotIgnore, error) {
+	ignore := &snapshotIgnore{}
+	for _, rule := range rules {
+		if strings.HasPrefix(rule, "/") {
+			ignore.pointers = append(ignore.pointers, strings.Split(rule, "/")[1:])
+			continue
+		}
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("ignore rule %s: %s", rule, err.Error())
+		}
+		ignore.patterns = append(ignore.patterns, pattern)
+	}
+	return ignore, nil
+}
+
+// apply replace the ignored values of val at the json pointer
+func (ignore *snapshotIgnore) apply(pointer string, val interface{}) interface{} {
+	if ignore.matchPointer(pointer) {
+		return ignoredValue
+	}
+	switch val := val.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for key, item := range val {
+			token := strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+			result[key] = ignore.apply(pointer+"/"+token, item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(val))
+		for i, item := range val {
+			result[i] = ignore.apply(fmt.Sprintf("%s/%d", pointer, i), item)
+		}
+		return result
+	case string:
+		for _, pattern := range ignore.patterns {
+			if pattern.MatchString(val) {
+				return ignoredValue
+			}
+		}
+	}
+	return val
+}
+
+func (ignore *snapshotIgnore) matchPointer(pointer string) bool {
+	if pointer == "" {
+		return false
+	}
+	tokens := strings.Split(pointer, "/")[1:]
+	for _, rule := range ignore.pointers {
+		if len(rule) != len(tokens) {
+			continue
+		}
+		matched := true
+		for i, token := range rule {
+			if token != "*" && token != tokens[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// findAssertion the first assertion of the builtin name in result
+func findAssertion(result *ApiResult, name string) *Assertion {
+	for _, assertion := range result.Assertions {
+		if assertion.Name == name {
+			return assertion
+		}
+	}
+	return nil
+}
+
+// diffLines the changed lines of two texts, prefixed by - and +
+func diffLines(src, dst string) string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(withNewline(src), withNewline(dst))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+	var changed []string
+	for _, diff := range diffs {
+		prefix := ""
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		default:
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(diff.Text, "\n"), "\n") {
+			changed = append(changed, prefix+line)
+		}
+	}
+	return strings.Join(changed, "\n")
+}
+
+// withNewline the text ending with a new line, so its last line diffs like the others
+func withNewline(text string) string {
+	if strings.HasSuffix(text, "\n") {
+		return text
+	}
+	return text + "\n"
+}
+
+// stringList the strings of a list or a comma separated string
+func stringList(val funny.Value) []string {
+	var list []string
+	switch val := val.(type) {
+	case []interface{}:
+		for _, item := range val {
+			list = append(list, fmt.Sprint(item))
+		}
+	case string:
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
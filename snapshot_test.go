@@ -0,0 +1,134 @@
+package pica
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Snapshot(t *testing.T) {
+	var count int32
+	name := "pica"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := atomic.AddInt32(&count, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": %d, "name": %q, "createdAt": %q, "tags": [{"id": %d, "name": "a"}]}`,
+			id, name, time.Now().Format(time.RFC3339Nano), id)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "users.fun")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+snapshotIgnore = ['^\d{4}-\d{2}-\d{2}T']
+
+// GET /api/users/1 getUser
+// snapshotIgnore: /id
+query = {}
+// Response
+snapshot(['/tags/*/id'])
+`), 0644))
+
+	run := func(update bool) *ApiResult {
+		runner := NewAPIRunnerFromFile(filename, []string{}, 0)
+		runner.UpdateSnapshots = update
+		runner.Reporter = NewOutput(false, new(bytes.Buffer))
+		runner.Run()
+		return runner.Results[0]
+	}
+
+	result := run(false)
+	assert.True(t, result.Passed, result.Error)
+	assert.Equal(t, "written", result.Assertions[0].Actual)
+	data, err := ioutil.ReadFile(filepath.Join(dir, SnapshotsDir, "users", "getUser.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "status": 200,
+  "body": {
+    "createdAt": "<ignored>",
+    "id": "<ignored>",
+    "name": "pica",
+    "tags": [
+      {
+        "id": "<ignored>",
+        "name": "a"
+      }
+    ]
+  }
+}
+`, string(data))
+
+	result = run(false)
+	assert.True(t, result.Passed, result.Error)
+	assert.Equal(t, "matched", result.Assertions[0].Actual)
+
+	name = "test"
+	result = run(false)
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Error, `-     "name": "pica",`)
+	assert.Contains(t, result.Error, `+     "name": "test",`)
+
+	result = run(true)
+	assert.True(t, result.Passed, result.Error)
+	assert.Equal(t, "updated", result.Assertions[0].Actual)
+	result = run(false)
+	assert.True(t, result.Passed, result.Error)
+}
+
+func TestAPIRunner_SnapshotAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "pica.fun")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+
+// GET /ping
+query = {}
+`), 0644))
+
+	runner := NewAPIRunnerFromFile(filename, []string{}, 0)
+	runner.SnapshotAll = true
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+	data, err := ioutil.ReadFile(filepath.Join(dir, SnapshotsDir, "pica", "GET_ping.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"status\": 200,\n  \"body\": \"pong\"\n}\n", string(data))
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Equal(t, "- \"name\": \"a\",\n+ \"name\": \"b\",\n+ \"age\": 1,",
+		diffLines("{\n\"id\": 1,\n\"name\": \"a\",\n}", "{\n\"id\": 1,\n\"name\": \"b\",\n\"age\": 1,\n}\n"))
+	assert.Equal(t, "", diffLines("same\n", "same"))
+
+	// lines are matched across the texts, beyond the first few
+	var src, dst []string
+	for i := 0; i < 30; i++ {
+		src = append(src, fmt.Sprintf("line %d", i))
+		if i != 2 && i != 11 {
+			dst = append(dst, fmt.Sprintf("line %d", i))
+		}
+		if i == 20 {
+			dst = append(dst, "new")
+		}
+	}
+	assert.Equal(t, "- line 2\n- line 11\n+ new", diffLines(strings.Join(src, "\n"), strings.Join(dst, "\n")))
+}
@@ -8,6 +8,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xb3\x8bLM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x00api_file_template.funUT\x05\x00\x01\x03\xda\xc0[TP\xbbN\xc3@\x10\xec\xef+\xb6\xbb\n\x0eZ$\x17\xe1!:@\"\xd0X.\x96\xf8\x82ml\xdf\xc9{A\x02\xcb\x05\x0dJ\xc3\xa3@\xa1\x85.\x1dt\x91\xe0{\x9c\xe43\xd0\xdd9\x04\xca\xd9\x99\x9d\xd9\xd9\x12\x0b	\x01\xf0\xba\xde<\xc2B6\x0dg\xb1\xa4A\x95j\x93\xaa\xb2c\xf6\xd7\x13+\xc0\x91IT\xd5q=\x07\xec\xf8ZV\xb4\xde9\xf7\xc8\x12\xec\x02I\x9eUy\xc7\xecz\xe4\x98Db,+\x82\x00j\x06\x00\xc0\xf7Tidi6\xfa7Zr\xbb\x80Z\xe7\xe9\x00m\xb6\xc8H\x95\x9c5\x8c	\x01=\x9d\x12\x0cUU\xa0\xd9\x81\xb0\x90&Qq\x04\xa1F\x93D\x10\xfe\xe9\x109\xf9\xe1A\x1f\x04\xeaT\x8c\xc8\xe6-\x1fg\xed\xd3d\xf12\x9d\x8fg\xed\xf8u\xf9>]\x9d\x12r\xdf(\xbdu\x99<\xb2GP>\xcc\x90\xf2\xaba\x96#\xc5\x99\x05A\xc0\x9d\xf1\xc9\xf1\xe9?\xe7\xf9\xe4\xb3\xfd\xfe\xf2\xceL+2\xbf\xdd\x84\x80.\xf0\xf9\xc1\x97\xb5\xcfw%\x8d$\xc3W\xa2\xf6\xe3~\xf1v\xe7\x15x\xe9\x04\xdb[\xac\xf9	\x00\x00\xff\xffPK\x07\x08\x9e\xa8<E\x1c\x01\x00\x00\xaa\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xed\x8bLM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00copyright.txtUT\x05\x00\x01n\xda\xc0[\xd2\xd7W\xb0%\x1e(\xe8\xebs\xe9\xeb+\xe8\xa2\x00\x85\x80\xcc\xe4D\x05\xc7\x82L\x05\xb7\xcc\x9cTtI\x84\x8e\x8c\x92\x92\x82b+}\xfd\xf4\xcc\x92\x8c\xd2$\xbd\xe4\xfc\\\xfd\xac\xd4\xa2\xd4\xdc\xc4\xcc\x8c\x9c\xfc|\xfd\x82\xcc\xe4D$\x1d\x84\x9c\x82\x04\x14\xf4\xf5\x01\x01\x00\x00\xff\xffPK\x07\x08\x9e\x0dP\xb5T\x00\x00\x00\xc7\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xc2\x90LM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00doc_template.htmlUT\x05\x00\x01\x8d\xe2\xc0[\xcc[\xfb\x92\xb2\xc8\x92\xff\x7f\x9e\x82\xe3\xc4\x89\xf3\xcd\xb1m\x01\xef\xce\xd7_l\x81\xf7\xf6\xd2\xb6\xda\x8a\x13\xb3\x11\x05\x14P\n\x14\x02\x8a\xba\xb1\x7f\xef\x1b\xec\xff\x1b\xfbn\xe796\xc0\x1b*x\x99\x9d\x8d=\x131\xfdi]~\x95\x99\x95\x99\x95\x99U~\xffK\xa5\xc7\x0f\x85\x8f*\xa5\xb9\x86\xfe\xe3\xa7\xef\xfe?\x94\x0eM\xf5-\xb1\xd5R|7\xf1\xe3\xa7\x9f\xbek\x08\xca?~\xa2\xa8\xef\x06r!%i\xd0v\x90\xfb\x96X\xbaJ\xaa\x988uh\xaek\xa5\xd0b\x89Wo\x89Ij\x04R<1,\xe8bQG	J\"\xa6\x8bL\xf7-\xd1\xac\xbe!YE\xa1y&4\xd0[b\x85\x91g\x11\xdb\x0d\x0d\xf5\xb0\xecjo2Za	\xa5\x82//\x146\xb1\x8b\xa1\x9er$\xa8\xa37\xc6\xa7\x8f\xa2\xbe\xbb\xd8\xd5\xd1\x8f\x7f\xfc\xe7\x7f\xfc\xe3\xbf\xfe\xfb{z\xf7\xcdow\xdc\xcd\xee\x13E\xfd\x8bBL7\xa5@	Q\xff\x164P\xd4\xbe\xc5\xc0\xfa\xa6L\x11\xc9\xc5\x121\x9d\x94\x8e\xcd\xf9\xaf\xfb\x11\x8e-\x95\xa9\xa5\xad\x7f\x93\xa1\x0b\xcb\xfe\xf8\xb4G\x14\xe5\xd7\x83\x0c\x02\x11\xfc*B\x07\xe5\xb3/2]\xaa\x7f\xaa\x80\x03\xc1\x7fS\xef\xf0\x89\xaf\xf5\xc1\xed\xff\xb8\xea\x88\xd6\x1b\x00\x80:\x0c\xbe\xab\xfe\x9ff\xf0q$\x0f\xbfF\xfe\xc7\xa9\xe4\x7f\xe5\x83.\x02@\x1f\x80!\xd3Yu\xfc\xef\x1b\x1f\x9fk\xf9=BM\xa8\x8e2\x9f3q\\\xf3\x00\x00\x95`Ru\xe4\xcf\x04\xa05\xd4VF\x97\x95y\xbfq8\x0fV\x0e\x16\xd9\xd1759S\xf4?\x16|P~\xe3w\xf3\xa3N\xb2\xd8\xd4La\xd2\xf5\xf1\xea\xc3\xf0$\x8e\x80&+;h,\x00\x00j\x8e\xdf\xf3\x11\x00yRuQra\xfdK\x9b\xfa\xdf\x9d\x80h\xda\xff\xd3U\xb5,,1\xc4\xef\xf3\xe9\x0bH\xe1\x88\xffw\xce\x83b\xe5\xb3\xae\xb9r\xc3\x1f\xdf.\xfa\x8d\x95`)\xaf\x0e\x00\xaf\x88\xf5\xd2L\xf0\xe9s\xc0Q><\xe00\x18r\xae0\xd1|\xfa\xf8E\x80\xa7\xee\x85X\x04\x03#\xa7\x89c\x9f\xff\xa1\xbf\x08\xd7\xf7\xbb\xf4\xc9\xb2\x90qzR\xbd\xb4\x95\xfdF\xecO\x05\xc8\xff\xd3\xa9f\xfaV\x8f\xf3$\xe3\xcbo\xac\x8a~c\xc3\xe7\x8fK\xc3Z\xdd\x9agfp(\xe4\xd7\xb0\xd8\x02\xf5\xce8\xdd\xcb\xb3\\\x05\xd3n\xab-\xf4\xb1)M\xaa\x1bKh\xe2zk6P\x1b&\xee\xe7\x97\xc6\xd0\x19U7m#\xc7}\xe5\xbb\x15\xee\xa38\xb4\\'_\xa3W\xc9y\x9a\x86&\x8b\x93\xd8mT\xbc\xcc\x8aM\x96\x92\x15\xee}\xb8uZ]s\xdc\xea\x0e\xd5Fu\x93\xe5\xd4z\xa6\xdai\x96*|\xb5\xd2\xabW'\xdb\n\xa8\x8cr\x1a\xf7\xdei\xaa\xdd\x8f\xe9\x82T2\x03\xac\x7f\xc1\xc9\x94\xaf~f\xd2\xcd\x02p\xd7\xd5V\xdb\xddn\x97S\xa5\x99\xfc\xfa\x9a[\xf6z\xa8O\x06\xda\xf8]\xcc\x0c9$\xd5\x19\xc6\xf6HW7\x0c\x93\xf9`\xc7\x82\xd4\x92\xb6z\x86E\xee\xc0z7\xb7\x98/\xe8\xfd\xcd\x181\x8e\xf1\xf5\xb1I\xb7\xdd\xc2\xbb\x94\xa4Wc!\xad\x02\xb5\xd9\xac.@\xb7\xe4!\xda\xf2\xde'6\xc2\x1d\xe8\xacWP\xac\xf4;\x9d\xac\x8d{\xc9\xc5\xba\xc3\x12\xd5\xab\xd4{\xd3\xe1d\xed\xad+x#\xf5\x9b\x12\x11j\\{\x96{\xcfT\x9bp \xb9`\xc1\xce\x87\x02\xf6\x92\x1bC\x93Pa\xe5uJ\xb3\xc1\xa2Wlm\xbe\xe4\xdcg\xa3\xa4n\x86.\x9bl\xa57#C\xd0\x9b\x9f\xb4Cg\xcd|\xb2\xf0e0d\x8b\xb6#\xd4\xae\xc2\xd1L\x83\x95\xc1r\xd2\xf0\xbe>\xd5U\xbbe2n\xbf\xb0\xc6\xcb\xafU\x9aH\xc3\xcfZ\x965\xba\xea\xb4\xce\xa9B]\xf4\xa6=\x0e\x03P\xab\xb7\xb8f\x07\x00\xbc\x05\xb5@\x150\xa87\xc1\xd6\x9cA\x81\xe5\xe6B\x1d\x80,6\x8b[o\x82\x93c6\xd9\x99\xf1\xdbN\xb3\x02\xac\x81\xb7\x9al\xf9Ra\x9am\xaa\xc5n\x9a[\x0b\xf5\xa9*\xa9z\x8e\xe5\xf8A\xff\x1d\x80\xcc\x8c\xff*\xf2\x00p\n8\xd8R\x95;\xae\x9fU2+\xc0\xf7\xa7}\xc05;\xb3w\x15\x18\x02x\xaf\xaa\xdcD\x05\x00u\xad\x99P\x17\xf2\xde\x10s\xeat\xcc\xa9\xec\xdc\x18\xadI\x05d;\x1fZ}\xda\x11\xb6\x1cf@c\xa3~\xb5\x85\xfe\x88\x87\xd0[T@\xf6\x83\xd7\xd6\x9a\xa1\xa5\x8b\xbdJ\xa5\xea\xac\x80\xd7P;\xef\x9df\xc5\xac\xb7\xe9uAm\xf5y\xe0u@K\xcev\x02\x0f\xd0\x08\xf8S\x85:\x04\xd9\n\xa8\x7f\xaaB\x7f\x0e\x1a\x9bz\xa7V\xec\xaa\x82\xdd\xecdZMP\xff\x12\x84\xca0	\xaa3\xe0-+5\x8b3@\xe9\xbdS\xa9z\x1d^+\xe1\xf4\xaa\xd8(:\x0d:\x9d\x95\xfb\x12\x83\x81\x01\xe6\xa0\x0dG\xefmu\x87?\x14J\xed\x8a\xd3T\xabM\xd1U\x17\x8d\xd1\x87U\xc1\x19\xf5\x83p_\x9b\xcf\xa11\x94\xe5\x9e\xb1\x18N\x86Zu\xb2\xb0\x89\xc8\xaa}\xa66\xf3\xac\xcaJ\xf1xN6\xe4	\x9f\x03_\xef\xb5e\x06\xe5:J\xb7\xd6bK\xef\xc3\xfe0[\xec\x89\xa5\xb4\xbe\x10\xbc^}\xbaF#\xa4w\xd9\x11\xfb\x99OJ\xc0V]\xbee\xc1\xe5\xb80\xeas\x0b\xb36\x1f93 \xa4\xe7\xbd\x11#}$+@]\xad=\x93\x91\xb4i\xc5\x1b\x89r\x9e\xafa\xa3>\xf1\xb6^-\xef~\x88\xb5\xa64\xab\xea\xc9\xd5\xca\xe8\xa4\xc5\x0d\xc8\x16Q\xde\x1d\xdb\xef\xc06\xb2\xd3\x96\xce\x8b\xb2c\xaf\xe7N\x9b\x01\xde\xd8Lo\xb8A\xeb\xdd\x12\xc4E\x11L \x1c\x8a\xc5\x80_\xb68\x03^\x8f\xa7\xe9\xa9\xcd\xa1~\xb7\xd2\xef\x8d{\xe9\xb4#s\xbe\x98?\xb10\x16@\xb5\xda\xaez\x9da5\xbb\xdc\x92\xdctKr\"\xcb\xade\xb3\xd6\x93@{\xdd\x9d\x81\xbc\xc8r\x9b\xa1\xe3\xf1\xc5\x99\xe0\xa9\xf4\x97\xde]\x12~8\x06\x9dEw\xdb\xd9:\xe4\x9d\xb1\xabZw\xc1m\xaa\x1bd\xab\xb9\x8fNK\x17\x96_KT\x1d\xbeKr\xbaXZr\x96i\xad\x9a\xd5/b\xa0F\x9bt\x1c\x00\x10\xd3\x94\xb3;\x15\x04\\\x96%\xe3\xfe\x90.\xf0}nX_\xd1-N\x83\xea\xbc\xd0\xe8o\xdf\xd7\x12d\x9d\x16_e\xb4\x8a\x9b\xed\xd7\x92\xa5Vo@\x9b\"\x84B\x85\xef+\x1e\xdf*\x80e\x064f\xc9v\x8f\xc9\xd4:\x86\x91\x97\xf4B\xa1\x98[\xad\x90I\xcf\xb9Y\x83\xe74\xc5\x12\x96]\x98\xfb\xd0\x18\x89F\xecd\x99\x99UW\xe3za$\x7fT\xda\xd3l\xb7\xc4\x9a=#Y\xe5&K 6\x8cfg\xf0\xd9q\x92Y8\xaa\xca\xd9\xae\x9c\xe1\x1b\x95bW^\xf5\xdaC\x07\xb0\xf5v\xb1S\xfa\xe8UD\xa9\x9d\xd4*\x05\x9eY\x13\xd8@\xed\xd6\xa0\n	]\xab\x8e\x99\xac4_\xf3\xc9\xe1\xa88\\\xaf\x1c!?\xa1Q\xfb\xc3\xf8\xd4\xec\x0d;\xfe\xc2\xa4m\xcdm\xd1*f\xdb\xed\xfeG\xbdY\x90\xf2N\x0f\x8f\xb6\xd6\xb89\x1e\xe4\xea[}\xa0\x8e\xb6\xdb67\xc0\xf3\xdeGm\xd8\x9b,\xf4M\xc1^\xac\xe9)\xd3\xcfq\xa0I\xa6\xdc\xa0\x86\xb5\xbe\xd0\xef\xf5\xb8\xaa<\xe7{\xead\xd8k\x00\xba\xd0\x00\xf5Y}\x8c\x9b3\xf81\xed\x8e\x99L:\xa9\x1b\xf9A\xa96,\xd8\xedF\xad\x95W\xfa\xe2\x1c\x0c{uf\xc6\xf6j\x9d\xa5\xf4\xdej9\xeb\xe6\x97\xd2\xef}\xea\xc9Rk#\xc3\xfc@g\xe4\x91\xa0\x0dx\x83\x917\xbc\xae\x10TY\xa1\xec\xa2#\xc8\xed\xaa\xa8,\x1aJ\xa6\x97\x06rei8\xb3`\xbf\x8c\xae*\x10\x00\xa6}a\xc6\x19\x1bP\x17\xfaSC\xd6\xda\xc5m[\xaeT72\xf8T\x08X4\xf7\xa7o\x07p\x1ex\x07\\\x07p\xe9t\xda?\xe7\xc0u\x88\xb1\x8f>\xde\xde~\xa1\x14b\x1b\xd0\xfd\xf67?v\xf9\xdb/\xbb\xe0\xe6\xdf\x7f\n\xfey5\xa0=\x97\x89g\xa6D\"o\x8e\xa1Q\xcapR.Z\xbb)\x07oQ\n\xca\xb3\xa5\xe3\x96)\x86\xa6\xffz\x08\x8dR\x1e\x12\xe7\xd8\xbd3J\xc7&Ji\x08\xab\x9a\xdf\xf1\x9a;\xb4KD'v\x99\xfa\x99\xcd\xb2%\x16\xfd\x1a\x15\x90\xa5\xa0e\xe9(\xe5l\x1c\x17\x19/\x14\xe7\xc7e\x1d(\x0d\x82\xef5b\xba/Tb\x80T\x82\xa8Q3\xf1B5\x90\xbeB.\x96\xe0\x0b\x05l\x0c\xf5\x17\xca\x81\xa6\x93r\x90\x8d\x95\x17*\x01|0\x8a\xf7\x97\xa5\xaa\x06\x99\xe1DhzD\xcb`c\x88DO\x9cQ\xe6sY\xa6\x98\xbc\xb5\xbe\xc7\x9eGl9\xe5\xd9\xd0*S\xa2\x8d\xe0<\xe57\xdc\x92\xfb\xab\xa5\xa7\xa4\xa3\xf4\x0f\xd2\xc9\xc3B\xa6p\x7f\x1e\xf3\x12\xd7\xe5\x04\x7fWW\xc04\x9d\x93\xa4\xdc=`\x14\x8b\x8b\xcckZ\x95,+1\xf7 \x1d\x03\xdf!\xd6a\xae\xa0\xc3Jr\x83Z\xd3\xbd\x9e\xc9\x16\xf3\x19xo\xe6\xfcj\x9e\\\xc8\xc0l\xe9\xde<'\x96\x15K\x8e\xef\xdb\xb1i9h\xcfn\xfc@\xfbF\xd7n\xe7\xa5\xf8-\xda\x0fq\xec\x07\x86\xc0k\x05\xc9\xb0J\x9e\xbd'\x80U<\xb4\xe1]a\xa2L\x9e\xa5\xef\nU\\^M\x143\x8c\xcc\x16\xefM\xc4\xf8j\xa2\x02\x15Q\x91\x0eF)Bi\xae\xdadi\xca\xa9\xe7\x90%\xf6y\xe4\xc74Hb\xcbe\x11)\xc4F\xa1\x15\x82\xec\xb7L%\xfe\xb5\x93\xb87\xff\xa4\x07\xe7I\xad\xb7wI\"\xd1\xe5+\x97\xfb\x90M\x18\xfa\x15\xcf\x85LN\xa2\x95\xbb\x13\xb5X\xa50\xb4\xbd\xa1\xc6\x8fp\x1e\xe7\xe31\x1ff\xe0s\xc4\xa0\"P\xa6\xb0\x0bu,\xdd:\x8dn@\x8a\x8f\x13\xf9 \xa2|S\xe7#\x15LQ\x10R\xe8\xbb\xc88\xc2\x9d\x86\x14 \x1a\x9aV\x14%{\x17\xfa\xfa\xbc\n[x\x1c\xd1\xa2|_\xf7p\x84\xc1\xe5\x95\xa2r\x8b\xe8\x07\xd5A\xb6\x1f\xdf\xbc\xc7\x8e4\xf1\xda{\xe6\x8ay:\x7f\xd7\xf8\x1d\xf5jb)W\x92\xe1]&$b\x9f\xac3\x88\xbfd$\x11\x1b\xba\x98\x98eji\xca\xc8\xf6\x03\x93+\x83\xb9\xef\xd3\xf7E\xb0#\xb8\x8c\x1dK\x87\x9b2\x85\xcd \xd4\x11u\"\x1d\x8bc+d\xfb\xf1\x96\x9e\x82:V\xcd\xf2\x8e\x12\x97X\x87~\x05\xebz\x99\x92\x96\xb6\x8dL7\x88\xbcn\xad}\x92\xe2\xf5\xe6\xba64\x1d\x0b\xfa87!\xcaPr\xf1*\xfa\xc0\x83e\x8d\xac\xd0i\xf7\xc9\xd2\x0dX\n\xea\x8ae\xea\xa6%9\xaeML5Zq\xb0\xa9!\x1b\xdf\xa4\xeb\xd6t_\xef\xd0M\xc1h\xcc\x85\x07\x0b\xa2P\x16\x19\x079\x1b\xd0V\xb1Y\xa6\xe8\xd7|\x01\x19\xb79\xc1\xc6\x89\x0e\x91\xd82\xb2\x0f\x1e\xd1$\xe6M?%\x119Z\xb0sQ\x8el\xb7\xec\x98J\xabAL\xe2XPB/\xa7\x8f\xbf^s\xc8\x1c8\x8c\x11\x8b\x1dbd\xed\xcf\xc1\xa6Z>\x1c\x9f)\x91\x1c\x83\xf4C|N\x1f\x1a|=Pt\xe2\x95\xa9\x15v\xb0\xa8\xdf\xe4\x1b\x9b\xd6\xd2=c\xe4b\xcfC\x1b\xf08\xceS$\xfc\xe6n,\xf4\x96\x904$\xcdE\xb2N\xfc\x1e\xc9\xf9~;C\x8c[P\x96\x83\xae\x9b\x84\xfd\xfd!\xb4G\x98:\xdb\xe5\x0b\x19\x856\xf6\xa2\xe7,\x87z\xc0\x9a\xa2\x82\xd5|^\xce\x1f\xf0\xae\x1c\xe2=\xcd\xbe\xf4\x0c\xf7<\xea\xf3F\x9e\xa7o\xee@H\x95\xaft\xf5\xa0[L\xceZGi\xb0\x86e\x19\x99\x87\xf6\x93\xeb\x8cp\x9a\x07\x8b\x0f\xa1\x1fw\xd9u\x89Q\xa6\x18kM9D\xc72\xf5\xb3\xac \x16\xdd<\x894\xfb*v=\x1e\x17.<\xaat8\xa2\xbd\x19\xcf\xfaxPqC\x1b\x11\x03\xa7#h\xfb\n\xeajO\xae\x10\xc0\\\xb9?\x0bJa#9vHD\xd7\xa1\xe5 \xdf\xa7\xec>\xdd\xc4\x8ev\x82\xaev\\\xef1k\xd4\xa2\xf3A\x8d\x8dn\xceD7g\xa3\x9bs\xd1\xcd\xf9#\x8d;m\xf3\x0f\xf1+\x15<*\xc9\x1d\xf2\xa3\x0e\xab\x0c{*\x99<e\x18l\xe4\xd1\x97\xfd\x83h\x99H4\xfa\x0f\xa2e\xa3\xd0\xc2\xc5\xa1\xa7\xd0r\x91h\x7f\x94\xd3|$\xda\x1f\xdc\x05\xeb)\xf5`\x8e\xf2\x8cF\x0bB\xc8\xc5\x92\xb8\xe8\x02\xf6\x8ef-\xf5H\xe5%\xfa\xa5\x81\xa5t\xa4\\{\xd0\xff\x85N\x13\x9d\"\xd1\x8b/\xf5\xf0\xfa:v\xf6\xf9e\xca?\xb0\xcb\x94N<d\xa7lb@\xf36g;\xa0\x1bK\xc4t\x92[3\xc9~\xe6=\xfa\xa0ni7S2Y\xbeT\x803	\xc7\x87\x8b\xd1\x81AbP\xeb\x10\x93\xa4>\x91\xba\xd4\xa1\x9dx\xa1xb:D\x87\xce\x0b\x95hc\x11\xed\x8e]\xca\x1f\x94x\xa1:\xc8\xd4\x89?ficd\xdf	\x19\xd9\xdb\xba\x17\x0eI\x9fP\x8b\x7f\x1e\x06\xae\x12\xb4\xc8\x1clG\xfeM\x1cKO\xd1q\x86C\xfd\x05\x1b\x16\xb1]x;\xdb\xf21\x98\x18\x8c\xac\xb5~\x02\x85\x8dA)>\x85\x92\x89A\xf1]\xf2\x130\xd9\x18\x18\xf69\x9er10\xfeQ\xf8\x04L>N\xc0\xf4\xa30\x7fj\x90\xf6\x7f\x1c\xa1\xfd\xf8{Y\xc1\xb6\xe3\xa6$\x0d\xebW^gg\xaa\x8fq\xfd\xe3\xefe\x1d\xc6\x01\x1d\xcd\xfb1,\n\x96M\xe2~\xfbM\xb3\x91\xf2\xfb/G\xb0}\xf6q\x91\xcd<\x9d}\xbcBS\xd2H\xa8*\xa5\x13\xe8\x96)\x7f\x9b\x7f\xbd\xd8z{w\\\x87\x82\x823\x7f\x9c\n\x872\xe77S\x0f\x10PV\x88\xb4<\x95_\xf7\xe5\x91\xfb\xf4[\x91\x87\xcf\xe9\x94\x8f9\xd5b\x8e\xac\xc8f9\xba9\xd0\xb9\xbb\xa5\x87G\xfc\xfc)j\x8b\x89\xa7\"\x12\xb4W6w*\xc1\\\xc6\xf6\xa7\x98&p\x1b\xa7\xd4\xe2\xban\x89\x18\x94E\xa7B\xefy\x86\xf6p\x08u\"\xe0T7\x89\xbeG<\xe67{o\x1f\xf0\xf1x\xd6wZ\xfb\xc7}[}\x14\xe8\x01S\xbd\x055\x17\xe5k\x8f\x14U\xb1<J)c\xad\xa9\xdcE4\xbc?\x81\x998\x1b\n\x19\xd7A\xae\xd9lV\xce\x1d\x0b\x0f\x97g\xb1\x81e\xf9\xe4\x14\xa3\xaa\xe1\xe7\x97H\xfb\xad\xdfm\x85\x9f\x8a\xff,\xe5%Qf.\xf6m'\x92TT\xc9\xf88\xc6\x862^:\x01\x9f\xa7\x9eu\xca\xd1\xa0L<_6\x0er)\x9aJ\xf9\xab\xd0\x8f\x94\x9d\xffy\xd2R\xf6\xda\xfd]\x9bq\\\x82suc\xcf\xde\xe1\xfa\x18q\x05\xcfBcd\xf0\xc8\xa0\xcc#\x83\xb2\x8f\x0c\xca=2(\x7f>\xe8\xf2\xd0\xfa\x99\x11\x19\x85\xcd<\xa6\xb9A\x85\x12\xeb\xd8\xdd\x9c\x97\x9b\xe2d\xb6/\xa7\xed\xcf\x95\x18\x99=2(\xf3\xc8\xa0\xec#\x83r\x8f\x0c\xca\x9f\x0f:\xca\xec\xe9#\xfdR\x04\x8fl\x18\xfb\xfc\x94\xcc\xf3S\xb2\xcfO\xc9=?\xe5R\x90\xd1\xaa\x18V\xab\x07\x8a\xe0\xdau\xaeq<\x1c^3\xa73/\xfa\x8e$\xbe\xd0\x89 \x92\xd0\xcd[m\xed:?\xb9\xb72\xf3\x9a\xfb\x93\xd6\x8e\xacY1\xa1\xe0#f^tu\xea\xce\xa4\xc8\"\x14\xfdZ,\xdc[-\xb2\xdeD\xbf\x16s\xb7c\x91h\xb4g+=\xecm\xe2\x82\xf2J|m%\xbevr\xa3\xe4\xf2Lpy3t\xd1O\xcf\x13\xae\xde\x8cA]\xbf=\xf5Gte\xee^4\xab\xe3dh\xd93\x16\xee\xea\x95|\xb5	w\x18\x94uJvc\xe7\xc4\x11\x1e\xad\xb5\x87\xd6\xa8'\x1c\xcf\x144}\x9a\xe4\xc8\xd09\xff@l\x11\x8dy~\xb7p\x8cB\xcf\xc2\xcf\xfdUs\xf8}\xe4\xe9\"\x07.]r\x7f\x81\xd0}\xc23\x1c\x1f&\xc7\xe7Q\x94\x1b!\x91\xbc\xb5\xa6\x98\xb3\x18r\x17\xa2>uO\xb4\x87\x0f]\xd7F\xbd\x0bQ.\xbc\xe5N!N\xeb\x84C\xe1\xdb\xeb\x94MW\xdb%\x13\xdfX\xf3\x97\x9b\xab\x86\xde\x94Dc\x86\xaf\xcb\x0d\xb8NE\xec\xe0\x9d\xcb\xe7[\xcc\xc6\xae\xf9[\x10\x87\xbd\x05\xf9\xfe\xefq^\xefN\x9d\xfd\x04\xe3\x8f\xbfF\xd9\x17\x13\xee\xc1\x9c\xd5qO\xa6\xf2\xca\"\x83\xa2_\xb3\x11\xaf\x10\",\xb8\x98\xfbk\xbc<lU\x84\xdf\xd8\xc2\x0b\x95a^\xa8L\xee\x85\xa2_\xe9\xdc/\x17\xeap\x95\xd0DS\x1bN\xfcC\xfe\xd4$\xb6\x01o:S\xcbF?bX}\x84\xc1\xb0B\x04\xeb\x06\x1e\xfc|a\x8a\xf24\xec\xa2\xe0\xc2\x11\x95\xfd\x15\xafe\xf2\xc8\xadm4\x03\xaf\x1aV5\xdd\xdf\xd3\xb8\x14\xfa\x9e\x07\x0b!D\xd4O\xaeJ\xe2ql>\x02~\xb7hs\x94~\xd8#G\xf9\xca85\xbbH\xf3\xb2\xb9[\xf6x\xfe\xb4,6\x83\xf6\x1d\xc0\x012C\xd3\xd7\x84\xa56e\xca\x91l\xa2\xeb7\xb5\xfd[&\xffB\xed\xfe?\xaay\xc87\xdcq\x0f\xbe\x98\xce4\xf5\xa2\xe2\x11\xa6v\xef\xae\xc2\xe2z@\xafc\xde\xa6\xdcz\xa9\x11km\x0f\xbd\xe6zT\xc5\x95\xa5\xae\xa7$b\x18\xd8\xa5^E\xd7L\x1d\x8a\x94&q\xbf\x95e\xec\xf8'\x80\xfc\xcb\xc5s\x8e\xa8\x07\x82\xe1K\xfe\x07_\x0f\xfe)E\xa6]}\xe9O\xbfA\xfa\x7f/T\xc9\x8c\x9c\x93/M\xe8\xa2P\x15Y\xccz\xb2Pu?\n(\x07\xaf\xa4\x90\x9c|\xf5\xa1IJ\x87\"\n\xc5\xac\xc4\xc1\xbb\x1c\xdeF:t\xf1\xea\xc8\xf56\x85M\x19\xad\x8f\xb5\xf2k\x0d	=2\x8a\xd1O\x17:\xf3Tp\xcb\x8a]d\x1c\x17\xbd\xbaw\xbdW?\xb8\x00J\xc6\x01\x87c\xe7;\x07\xe3%\xc4\xf9\xc3\xad\xa3\x0b8\x1e\xecAQ8\xc5\xbc\xe6O\x07\xfc-\xad\x89I\x0b\xc3O\xf4\xa2T\x02\xa1\xe3|\x8a\xfa\x9e\xde\xffZ\xfb{z\xf7\x83\xf3\x9f\xbe\xfb(\xc1\xef\xb8e\xbc\xa2$\x1d:\xce[\xc2\x8ff\xf6\xbf\xfaN\xcbxu\xd9\x1d\x845\x89\xddO\xbe\xbfC\x9fb\x1d\x1d\xfa\xce\xa9C\xa6koR\xfb\x90-A\x05\x8b\xbf%N\xd6\xeb\x9b\xd1\x1e\x88\xa2~\xf3\xa7\xfc\xbeCM\xefa\x7f\x9ch\xf8\x9e\xde\x91\xea\xd3\xee\x1a\xfa\x8f\xff	\x00\x00\xff\xffPK\x07\x08\x10[\xf2''\x11\x00\x00X?\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xda\x8bLM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00doc_template.mdUT\x05\x00\x01M\xda\xc0[\xac\x93\xd1k\xfa0\x10\xc7\xdf\xf3W\x1cT\xf0\xf7\x03-{.Lp\xeeA\x1f\x94\xcd\xb1\xbd\x07{\xb3\x99m\xd25\xa9\xac\xa4\xf9\xdfG\xd2V\xcd\xda9\x06{i.w\xc9'\xc7\xf7{\x0d@\xebpC34\x86\x90\x99\xdd\xdc\xa3\xdc\x15,WLp\x9b{\xc1B2\xc1#[jc\x9b\x9e\x97*\x11\x85\xcb6\xa1M\x06\x01\xac8S\xf0\xb4\x139\xdam\x00K\xa41\x16\x92\x90\x1a8\xcd\xb0\x86#MK\x84\x1a\xe2\xf33P\x93\x1a\xa6\xd3)x_\xa2uA\xf9\x1ea\xc4&0:Bt\x0baK3\xa6\x06\xadG\xcc\x18p\xc1\xd1\x05\xcd\x1d\xe4q\xdb\xca\xfcaE|\x08S\x989\xce<g+\x85\x99\x04c\\\x97\x16\xa60\x0b\xb7\xf8^\xa2T\xe1\x1aU\"bcz\x85\xe7\"\x1d\xc8\xb6\xfai\xcd^\xc1\xaf\xf8b\xcez7\xfdz\xd7\xfd\x00\xe8\xb1\xc4\xa2j\xba\x0d\xc0m\x065kT\x86\x1aT\x95\xf7U\xbe\xc2\xbf\x13\xf1	o\xe3\x1fHW\xfd:`e\x1d\xa3\xa9\xd5\xda\x7f\xc67\xf0\x80\xd5\xc9B\x9a\xf6L\xbc03\x08`\x8b2\x17\\\"!-$\xfa\x9b\xa1\xea\x1al\xe8\xbf\x181O\xc5\xf6v+\xa3]\"2\x1e\x8f\x89\xd6oR\xf0o\x8eu\xa8\xe6\xe0%\x96#\xfcK\x91C\xf7\xcbm\x84B\x19.\x12\xdb\xbb\xfc\x0f7\xce*\xd8bJ%\x82+^\x8e:\x8f\xf1cr\x9e\xf6!\xc6\x97\xc1_\x88,c*\\R\x994=x\xe95JI\xf7\xe8M\xa8[?\x03\x00\x00\xff\xffPK\x07\x08\xdd\xeb\xa6\xb8\x80\x01\x00\x00>\x04\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xb3\x8bLM\x9e\xa8<E\x1c\x01\x00\x00\xaa\x01\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00api_file_template.funUT\x05\x00\x01\x03\xda\xc0[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xed\x8bLM\x9e\x0dP\xb5T\x00\x00\x00\xc7\x00\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81h\x01\x00\x00copyright.txtUT\x05\x00\x01n\xda\xc0[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xc2\x90LM\x10[\xf2''\x11\x00\x00X?\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x02\x00\x00doc_template.htmlUT\x05\x00\x01\x8d\xe2\xc0[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xda\x8bLM\xdd\xeb\xa6\xb8\x80\x01\x00\x00>\x04\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81o\x13\x00\x00doc_template.mdUT\x05\x00\x01M\xda\xc0[PK\x05\x06\x00\x00\x00\x00\x04\x00\x04\x00\x1e\x01\x00\x005\x15\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00uZXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x00api_file_template.funUT\x05\x00\x01\xcfi\x17bTP\xbbN\xc30\x14\xdd\xfd\x15w\xf3\x04\x86\x15)Cy\x88\x0d\x90(,Q\x86K\xe3\x92\x84$\xb6|]$\x882\xb0\xa0.<\x06TV\xd8\xba\xc1V	\xbe'm?\x03\xd9I)\x8c\xc7\xe7u\x8fK,$\x04\xc0\xabj\xf3\x08\x0bY\xd7\x9c\xc5\x92\x06&\xd56Ue\xc7\xec\xaf_\x9c\x00G6Q\xa6\xe3z\x1e\xb8\xe7kih\xed9o\x91#\xd8\x05\x92<3y\xe7\xd8m\x91g\x12\x89\xb14\x04\x01T\x0c\x00\x80\xef\xa9\xd2\xca\xd2n\xf4o\xb4\xe4\xce\x80Z\xe7\xe9\x00]\xb7\xc8H\x95\x9c\xd5\x8c	\x01=\x9d\x12\x0c\x95)\xd0\xee@XH\x9b\xa88\x82P\xa3M\"\x08\xffl\x88\xbc\xfc\xf0\xa0\x0f\x02u*F\xe4\xfa\x96\x8f\xb3\xe6i\xb2x\x99\xce\xc7\xb3f\xfc\xba|\x9f\xaeN	y\xbb(\xbd\xf5\x9d<rGP>\xcc\x90\xf2\xaba\x96#\xc5\x99\x03A\xc0}\xf0\xc9\xf1\xe9\xbf\xe4\xf9\xe4\xb3\xf9\xfej\x93\x99Vd\x7f\xb7	\x01]\xe1\xf3C;\xd6}\xbe\x1fi%Y\xbe\x125\x1f\xf7\x8b\xb7;\x8f8^J\x0e\x01lo\xb1\xfag\x00PK\x07\x08\x9e\xa8<E\x19\x01\x00\x00\xaa\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00uZXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x00copyright.txtUT\x05\x00\x01\xcfi\x17b\xd2\xd7W\xb0%\x1e(\xe8\xebs\xe9\xeb+\xe8\xa2\x00\x85\x80\xcc\xe4D\x05\xc7\x82L\x05\xb7\xcc\x9cTtI\x84\x8e\x8c\x92\x92\x82b+}\xfd\xf4\xcc\x92\x8c\xd2$\xbd\xe4\xfc\\\xfd\xac\xd4\xa2\x9c\xfc|\xfd\x82\xcc\xe4D$\xc5\x08\xfb\x08\x02\x05}}\xc0\x00PK\x07\x080^\x87\xbdK\x00\x00\x00\xc2\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00uZXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x00doc_template.htmlUT\x05\x00\x01\xcfi\x17b\xcc;\xe9r\xf2H\x92\xff\xfd\x14\x1a:&\xa6{0F\x12\xe2\xf4\x11[\x12\xb79\x8c\x01st\xf4F\x94\xa4\x92T\xa0\xcb\x92@\x80\xe3\xfb\xbdo\xb0\xff7\xf6\xdd\xe696J\xe6F\x08\xf8\xa6gc\xbe\x88\xa6MUeVfV\xdeU<\xfd\xa5\xd8\x16z\xa3\xb7\x12\xa5y\x86\xfer\xf7D\xfeG\xe9\xd0T\x9fc+-!\xb4b/wwO\x1a\x82\xf2\xcb\x1dE=\x19\xc8\x83\x94\xa4A\xc7E\xdesl\xe6)\x89\\l7\xa1y\x9e\x9d@\x9f3<\x7f\x8e\x0d\x13}\x90\x10,\xc3\x86\x1e\x16u\x14\xa3$\xcb\xf4\x90\xe9=\xc7j\xa5g$\xabh\x0f\xce\x84\x06z\x8e\xcd1\xf2m\xcb\xf1\xf6\x96\xfaX\xf6\xb4g\x19\xcd\xb1\x84\x12\xc1\x97{\n\x9b\xd8\xc3PO\xb8\x12\xd4\xd13C\xe8\xa3\xa8'\x0f{:z\xf9\xc7\x7f\xff\xd7?\xfe\xe7\x7f\x9f\x92\xdf\xdf\xc8\xb8\xeb-\xbf\xff\xa2\xa8\xffP,\xd3K(PB\xd4W0@Q\xeb\x11\x03\xeb\xcb\x02eI\x1e\x96,\xd3M\xe8\xd8\x9c>\xaeW\xb8\x8eT\xa0f\x8e\xfe\xab\x0c=X \xeb\x93\xbe\xa5(\x8f\x1b\x19\x04\"x\x14\xa1\x8b2\xdc\xbdL\xe7+\xef*\xe0A\xf0o\xeco\xfe\x12\xca\x1d\x10\xfd\x8f/\xf5i\xbd\n\x00\xa8\xc0`@%\x1f5\xf2\x01\xfar\xef\xa3O\xfe\x1cK\xe4\xab\x10LY\x00t\x00\xe81\xcdy\x93|_\x12\xfc|\x9d\xcc\x8c\xca\xa3R?\xf5>\x11\x07e\x1f\x00P\x0c\x80J}\x02	@\xbd\xa7\xcd\x8d\x16+\x0bd\xb07%\x9f\xdf$\x12x\x00\xc6&o\x8a\xe4\xcf,A*,\xc9\xb4\xd0o\xc6s5\xcd\x1c\x0d[\x04_\xa5\xb7\x0f\xc4[\xa0\xc6\xca.\x1a\x8c\x00\x00e\x97\xcc\xbc\x91\x99\xb1/\x95>\xf3\x1e\xac|hc\xf2\xdd%\xf8\x00M>Z\xaa\xc6\xc1<c\x919B_@\no\x91\xcf\xa9\x00r\xc5\xf7\x8a\xe6\xc9U\xb2\xbe\x91#\x83E\xf2\x01\xfc\n\x00\x82\"V\xf2\x93\x11\xa1\xcf\x05[\xf9\x08\x80\xc7\xa0\xc7{\xa3\xa1F\xe8\x13>\xc9\x14\xaf\xae\x85\x98\x03]#\xad\x89\x03\xc2\x7f\x8fl\xc2w\xc8\x94>\x9ceSn[\xaa\xe4W2\x19\xc4\x04\x14 \xf2\xd1,\xa5:v\x9b\xf7%\xe3\x83\x0c\x96D2X%\xfc\xf1IX\xae\xd8\xd3\xd4\x04\xf6F\x99\x05\xcc\xd5A\xa59H\xb63,_\xc4\xb4Wo\x8c:\xd8\x94\x86\xa5\xa5=\xaa\xe1J}\xd2U\xab&\xeedfF\xcf\xed\x97\x96\x0d#\xcd\x7fdZE\xfe-\xd7\xb3=7S\xa6\xe7\xf1i\x92\x86&\x8b\xe3\xd8\xab\x16\xfd\xd4\x9c\x8d\xe7\xe3E\xfe\xb5\xb7r\xeb-sPo\xf5\xd4ji\xc9\xf1j%Uj\xd6\xf2E\xa1TlWJ\xc3U\x11\x14\xfbi\x8d\x7fm\xd6\xd4\xd6\xdb\xf8\xd3*\xa6\xbaX\xff\x80\xc3\xb1PzO%kY\xe0-J\xf5\x86\xb7Z\xcd\xc6J-\xfe\xf11\xb5\x9dEO\x1fv\xb5\xc1\xab\x98\xea\xf1H\xaa0\x8c\xe3[-\xdd0L\xe6\x8d\x1d\x8c\xa4\xba\xb4\xd2S,\xf2\xba\xf6\xab\xb9\xc2BV\xef,\x07\x88q\x8d\x8f\xb7e\xb2\xe1e_\xa58=\x1f\x8c\x92*Pk\xb5\xd2'h\xe5}D\xdb\xfe\xeb\xd0A\xb8	\xdd\xc5\x1c\x8a\xc5N\xb3\xc99\xb8\x1d\xff\\4YK\xf5\x8b\x95\xf6\xb87\\\xf8\x8b\"^J\x9d\x9ad\x8d\xca|c\x92~M\x95j\xb0+y\xe0\x93\x9d\xf6F\xd8\x8f/\x0dMB\xd9\xb9\xdf\xccO\xba\x9f\xed\\}\xf9!\xa7\xdf\xabyu\xd9\xf3\xd8x=\xb9\xec\x1b#\xbd\xf6N\xbb4gf\xe2\xd9\x0f\x83\xb1Vh\xd5G\x8d\x12\xecO4X\xec\xce\x86U\xff\xe3]\x9d7\xea&\xe3u\xb2\x0b<\xfb\x98'-\xa9\xf7^\xe6X\xa3\xa5\x8e+\xbc:\xaa\x88\xfe\xb8\xcdc\x00\xca\x95:_k\x02\x80W\xa0\x1c\xa8\x02\x06\x95\x1aX\x99\x138b\xf9\xe9\xa8\x02\x00\x87\xcd\xdc\xca\x1f\xe2\xf8\x80\x8d7'\xc2\xaaY+\x02\xbb\xeb\xcf\x87+!\x9f\x1ds55\xd7J\xf2\x8bQe\xacJ\xaa\x9efy\xa1\xdby\x05 5\x11>r\x02\x00\xbc\x026\xb6T\xe2\xb7\xfbsJj\x0e\x84\xce\xb8\x03\xf8Zs\xf2\xaa\x02c\x04^K*?T\x01@-{2\xaa\x8c2~\x0f\xf3\xeax\xc0\xab\xec\xd4\xe8/\xac\"\xe0\x9aoZe\xdc\x1c\xadx\xcc\x80\xeaR\xfdh\x8c:}\x01B\xff\xb3\x08\xb87A[h\x86\x96\xcc\xb5\x8b\xc5\x92;\x07~Um\xbe6kE\xb3\xd2\xa0\x17Y\xb5\xde\x11\x80\xdf\x04u\x99k\x06\x1e\xa0\x1a\xf0\xa7\x8e*\x10pEPyWG\x9d)\xa8.+\xcdr\xae\xa5\x8e\x9cZ3U\xaf\x81\xca\xc7hT\xec\xc5Ai\x02\xfcY\xb1l\xf3\x06\xc8\xbf6\x8b%\xbf)hy\x9c\x9c\xe7\xaa9\xb7J'9\xb9#1\x18\x18`\n\x1a\xb0\xff\xda \xb6T%\xf6\x90o\x14\xdd\x9aZ\xaa\x89\x9e\xfaY\xed\xbf\xd9E\x9cR\xdf,\xfec\xf9\xde3z\xb2\xdc6>{\xc3\x9eV\x1a~:\x96\xc8\xaa\x1d\xa6<\xf1\xed\xe2\\\xf1\x05^6\xe4\xa1\x90\x06\x1f\xaf\xe5Y\n\xa5\x9bJ\xab\\g\xf3\xaf\xbdN\x8f\xcb\xb5\xc5|R\xff\x1c\xf9\xed\xcax\x81\xfaHo\xb1}\xf6=\x13\x97\x80\xa3zB\xdd\x86\xb3A\xb6\xdf\xe1?\xcd\xf2\xb4\xefN\xc0(9m\xf7\x19\xe9-^\x04\xea|\xe1\x9b\x8c\xa4\x8d\x8b~_\x943B\x19\x1b\x95\xa1\xbf\xf2\xcb\x19\xefM,\xd7\xa4II\x8f\xcf\xe7F3).\x01\x97C\x19o\xe0\xbc\x02\xc7\xe0\xc6u]\x10e\xd7YL\xdd\x06\x03\xfc\x81\x99\\\xf2\xdd\xfa\xab=\x12?s`\x08aO\xcc\x05\xfc\xb2\xb9	\xf0\xdb\x02M\x8f\x1d\x1euZ\xc5N{\xd0N&]\x99'b~\xc7\xa3\xc1\x08\x94J\x8d\x92\xdf\xec\x95\xb8\xd9\xcaJ\x8fWVZd\xf9\x85l\x96\xdb\x12h,Z\x13\x90\x11Y~\xd9s}!7\x19\xf9*\xfd\xa1\xb7f\x96\xd0\x1b\x80\xe6gk\xd5\\\xb9\xd6+\xe3\x94\xb4\xd6'\xbf,-\x91\xa3\xa6\xdf\x9au}4\xfb\x98\xa1R\xefU\x92\x93\xb9\xfc\x8c\xb7M{^+}X\x06\xaa6\xac\xa6\x0b\x00bj2\xb7\x89'\x1ck\x0d:=:+t\xf8^eN\xd7y\x0d\xaa\xd3l\xb5\xb3z]H\x90u\xebB\x89\xd1\x8a\x1e\xd7)\xc7\xf3\xf5v\x976E\x08GE\xa1\xa3\xf8B=\x0bf)P\x9d\xc4\x1bm&Un\x1aFF\xd2\xb3\xd9\\z>G&=\xe5'U\x81\xd7\x14{4k\xc1\xf4\x9b\xc6H4b\x87\xb3\xd4\xa44\x1fT\xb2}\xf9\xad\xd8\x18s\xad<k\xb6\x8dx\x89\x1f\xce\x80X5j\xcd\xee{\xd3\x8ds\xb0_\x92\xb9\x96\x9c\x12\xaa\xc5\\K\x9e\xb7\x1b=\x17\xb0\x95F\xae\x99\x7fk\x17E\xa9\x11\xd7\x8aY\x81YX\xb0\x8a\x1a\xf5n	Zt\xb94`8i\xba\x10\xe2\xbd~\xae\xb7\x98\xbb\xa3\xcc\x90F\x8d7\xe3]s\x96\xec\xe0\x03[\x0d{\xea\x88v\x8ek4:o\x95ZV\xca\xb8m\xdc_\xd9\x83\xda\xa0\x9b\xae\xac\xf4\xae\xda_\xad\x1a|\x17O\xdbo\xe5^{\xf8\xa9/\xb3\xce\xe7\x82\x1e3\x9d4\x0fj\xd6\x98\xef\x96\xb1\xd6\x19u\xdam\xbe$O\x85\xb6:\xec\xb5\xab\x80\xceVAeR\x19\xe0\xda\x04\xbe\x8d[\x03&\x95\x8c\xebF\xa6\x9b/\xf7\xb2N\xa3Z\xaeg\x94\x8e8\x05\xbdv\x85\x99\xb0\xedrs&\xbd\xd6\xeb\xee\xa2\xf6\xa1t\xda\xefz<__\xca0\xd3\xd5\x19\xb9?\xd2\xba\x82\xc1\xc8KAW,T\x9c#\xee\xb39\x92\x1b%Q\xf9\xac*\xa9v\x12\xc8\xc5\x99\xe1N\x00\xf8\xf6\x17#\x0b\x80qg4\xe1\x8d%\xa8\x8c:cC\xd6\x1a\xb9UC.\x96\x962xW,\xf0Y[G\xdf&\xe0}\xf0\n\xf8&\xe0\x93\xc9$\x00\xb9M\x08\xdf\xff\xb7\xce>\x9e\x9f\x7f\xa3\x14\xcb1\xa0\xf7\xeb\xdfH\xee\xf2\xb7\xdf\xbe\x93\x9b\x1fw\xc1\xff\x1e\x0c\xe8Le\xcb7\x13\xa2%/\xb7\xa9Q\xc2p\x13\x1eZx	\x17\xafP\x02\xca\x93\x99\xeb\x15(\x86\xa6\xff\xbaI\x8d\x12>\x12\xa7\xd8\xbb\xb0J\xc7&Jh\x08\xab\x1a\x01\x7fHo\xc6%K\xb7\x9c\x02\xf5\x0b\xcb\xb1y\x16=\x86%d	h\xdb:J\xb8K\xd7C\xc6=\xc5\x93\xbc\xac	\xa5n\xf0\xbdl\x99\xde=\x15\xeb\"\xd5BT\xbf\x16\xbb\xa7\xaaH\x9f#\x0fK\xf0\x9e\x02\x0e\x86\xfa=\xe5B\xd3M\xb8\xc8\xc1\xca=\x15\x03\x04\x19%\x90m\xa9\x92aMpl\x0f<d\xa4\xbb4DK\x8f\x1dPF\xb8,PL\xc6^\\b\xcf\xb7\x1c9\xe1;\xd0.P\xa2\x83\xe04A\x06\xa2\xe4\xfe`\xeb	\x89\xfa:\x92N\x06fS\xd9\xcbp\xcc\xfd9\x94n\x80x~\x82\x98\xa6\xd3\x92\x94\x8eR\x04\x02\x87\xce\xe2E\xe6)\xad\n\xc7J\xcc%\x94\xae\x81/\x10\xeb2\xd4W\x84\x92\x84\xab-\x01D\xa6w\n\xc9\xe62)x\x89\xa8\xe9	\x9c\x9cMA.\x7f\x91\x99\xb3\xac\xd8\xf2\xf9\xb9\xef3\xb1]\xb4f\xf7\xfcB'b*\x00\x96\xa4\xf3G\xe4:\xeb\x95W,\x81'\x02\xa0S\xac\x92a/	`~\x1e\xb5\xe1\x9f\xe0D\xa9\x0cK_\x14\xaa8;\x01\x14S\x8c\xcc\xe6.\x01b|\x02\xa8@ET\xa4\x8d\xb1\x8aP\x9a\xaa\x8e53\xe5\xc4m\x98%\xf6v\xcc\xd7i\x90\xc4\x16\n\"R,gW\x94\xae\xab\xdf\x02\x15\xfb\xcff\xec\x12e\xeb\x13\x94\x8e\x8bZ\x7f\xed\x92DK\x97\x1f\x8f\xad\xe9*\x9b0\xf4=\x8a\xbe\x01\xb3\xa9\xb4D+\x17\x01\xb5\xb3JahkC=\xbf\xc2\xbd\x9e\x8f\xeb|\x98\xb1\xd3\x8a\x00c\xd0\x11(P\xd8\x83:\xdejFX4\x8a\x10\x8dx=\x91Wb\x94\xa9\xaf#R\xf653T\xc1\x14\x05!\x85\xbeH+\x0eq\xa7{\n\x10\x8e\x9aV\x14\x85\xbb\x88Z\x8a\xb4\xf0sD\x8b\xf2E\x7fl\xe0\x10\x83\xcb(9\x05F\xa0\xbeR\x1dd\xe7zK\xb9.\xa4\x89\xa7\xde3\x9d\xcb\xd0\x99\x8b\xc6\xef\xaa'\x80\xf9t^\x86\x17\x99\x90,gg\x9dA\xfe%#\xc9r\xa0\x87-\xb3@\xcdL\x199$\xefz\xbc\xdd\xa7\xaf\x9b`[\xe42vm\x1d.\x0b\x146	\xc6\x84\xa8[\xd2\xb696G\x0e\xc9\xb7\xf4\x04\xd4\xb1j\x16\xbe)\xf1,{3\xaf`]/P\xd2\xccq\x90\xe9\x05\x99W\x14c;)\x9e\x1e\xae\xe7@\xd3\xb5!\xc1\x13\x89\xa2\x00%\x0f\xcf\xc3\x03\x1e,h\xd6\x1c\xedN\xdf\x9ay\x01KA_\xb1@EZ\x92\xeb9\x96\xa9\x86\xbbXlj\xc8\xc1\xde\xcf\x82\x13\x0f\x8d\"\x05\xa3\xed,x/\x0be\x91\xb1\x91\xb3\x01\x1d\x15\x9b\x05\x8a~\xc8d\x91\x11\xcd	6vl\x88\x96##g\xe3\x11M\xcbDQ\x90\x92%\x87\x0bv*\xca\xa1\xe3\xb6s\xa6\xd3jX\xa6\xe5\xdaPB\xf7\xbb?\x1fO9d\x90\x11\xc5\x89\xb6;J\xd1Z\x90\xdc\x1c\x9bja\x13>\x13\xa2\xb5M\xd27\xe5\xc7Z0\x14E\xf4@\xd1-\xbf@\xcd\xb1K\xda\xd3\x91\x123\xed\xd9.\xb1$'pt\xe6{\x07p=\x9e\x9bH\xf8\xdd[\xda\xe89&iH\x9a\x8a\xd6\"\xf6G(\xe7\xeb\xe3\xdcc\xdc\x86\xb2\x1c\x08%\x92\xb0\xbf_\x85\xed\x1a\xa6\x0e\xfa\xe9G2\xdaS\xdd\xa3\x99\x83\x1a\xea`\xee\x92\xa3\xd8\x84m:\x95\xc9\xc8\x99\xc7s\x0e\xf1\x92f\x1f{\x86K\x1e\xf5v\x1f\x91\xa1\xe9+U\xf9DW7\xba\xc5\xa4\xedE\x98\x06kX\x96\x91\xf9x\xe2:C\x9c\xe6\xc6\xe2\xb7\x8a\xbau\x01\xa2\xe5y\x96Q\xa0\x18{A\xb9\x96\x8ee\xea\x17YA,\x8a\x8cD\x9as\x92\xbbn\xc3\x85\x07\xb7*\xbd\x9f\xd1F\xe6\xb3\x04\x1fT<\xe4\\B\xa7#\xe8\x10\xc7\xe9i\x8f'9s\xe4\x0e\x01\x1a\xea\xeb\x90w\xe2\x89\xf6\x8dd;!Y\xba\x0em\x17\x11\x9f\xf2\xfdW$\xeep'\xe8i\xd4\xd7M\xd6\xa8\x85\xd7\x83\x1a\x1b>\x9c\n\x1f\xe6\xc2\x87\xd3\xe1\xc3\x99-\x8d\xdf\xdaF\x82\xf8\x9e\x92\xac\x077Jr\x81|\xea\xeb\xd4\xe2S\xec\xaeer\x93a\xec\xf2\xc0\xfd\xd0\xc7\xfd$\xb6T(6\xfa'\xb1q\xd4Wts\xe8&N\xd3\xa1\xd8~\x96\xd3L\x98\xdc\x98\x9f<\x05\x9b\xfa:\xd4\x84H\xf5`\xb6\xf2\x0c\x97[\x90B~\xce,og\x89W\xc5\xcf\x99\x1e\xaa\xbc\xd6.\x13^\x87\xbb\x84\x8e\x94S\x0f\xfaO\xe8\xb4\xa5SV\xf8\xe63}\x7f\x7f\x1d\xbb\xeb\xfa2A\x02v\x81\xd2-\x1f9	\xc72\xa0\x19%\x91\x99N\xcd.lqf\xd2\x8a\x82\xb4\xd6\x90\x97\xe8\x83\xba\xadE\x96d\xf2\xae:]\x9f\xf5\x81\x84\xc3\x8f\x99\xa4\x8b\xe1\x89A\xac[nZ\xa6\x95xG\xeaL\x87N\xec\x9e\x12,\xd3\xb5t\xe8\xdeS\xb1\x06\x16\xd1w\xd8\xa5\xc8\xa2\xd8=\xd5D\xa6n\x9153\x07#\xe7B\xca\xb8\xd5\xf0\x1fw\x97R\xd2\x1b\xd4\xe2\xdf\x87\x81\x93\x02-\xb4\x06\xfb6\xc5H<\xb6\x9e\xa0\xcf\x19\x0e\xf5\x17l\x90\x97\x180\xba\xda\"8\x983\xc6\xc7\xd9\x8b\x1b\xb0\xb0gL8w\x13\x96\xd4\x19G\xc0dnB\xc3\x9d\xf1'\xecm<\xa5\xcf\xb8\xa5\x14{\x135\x993\xde\x8d\xa3\xaf\xa5\xe6OM\xd2\xfe\xc5\x19\xda\xcb\xdf\x0b\nv\\/!iX?\xf1:\xdf\x1e\xfc:\xae_\xfe^\xd0\xe19D\xdbL\xe6:\\\x14,\x98\x96\xf7\xeb\xef\x9a\x83\x94?~;\xae>\x8e\xaa\x99\x9b\xab\x8f\x07hJ\x9a\xb5Kz\x15\xdd\x82^\x81\".\xf6\xf1(\xb09\xdf\xe1z/)X3D\x16\x17\xa8\xc4~*sx3u\x05\x01\x05\xc5\x92f\xeeq{\xe42\xfd\xf6}t\x94\xbf\xbf)\x98\x87\xae\x96\xc3\x87\x83t\xfe\xfe\xcf\xf0\xf3\xbb+\xbd\x8b\xbd\x86m\x81\xf6\xc0\xa6w-\x98\xe3J{\xa3\xb3k\xb7A?\x9emm\xfd\x82\x18\xc4\xa1]\xa37\xa8=\xb6\xd9\xcb\x8f\xbbh\xe1R_'\x04\xec\xfa&\xe1\xf7\x88\xdb\xfaf\xed\xed\x03>\xae\xaf\xfav{\xbf\\\xb6\xd5k\x11]a\xaaQ\xa8\xa6\xa2|\xea\xdd\xc2:\x96\xdbcJ\xd9\x0b*m/\xc2\"0s\xce\x86\xf6\x8ck#W\x8e\xe3\xe4t\xe6\xf1L,6\xb0,\xef\x1clH\xcb\xfa\xe8\x12i}\xf4\xdfGAJ\xf1_\xa4\x8c$\xca\xcc\xd1\xb9}+m\"\xace\xbc=[\x07\xcax\xe6\x06|\xeef\x16	W\x832\xe99a\xd3E\x1eES	\xb2\x0b}M\xdb\xf9\xdf\xa7,\xdd\xaf\xfe\xce\x9a\xf1\xb9\xe2\xeb\xe4A\x02\x1b\xdd\xe2`\xb6\x19W\xf0,\xf4\x8c\x0c\xaeY\x94\xbaf\x11w\xcd\xa2\xf45\x8b2\x87\x8b\xb6\xe6\xb1\xd1\x1aFd\x146\xf5x&\x8b<\xd4\xdc\xa0I\x8au\xec-\x0f\xdbM\xe74e\xddN[\x07\xb6p\xfa\xd8k\x16\xa5\xaeY\xc4]\xb3(}\xcd\xa2\xcc\xe1\"\xea\xebgC\xfa\xb1\x08\xae90\xf6v\x90\xd4\xed \xdc\xed \xe9\xdbA2Q\xecS_!juE\x1f~\xaf\xb3\xb4v\xe3\xdb\xf0M?\xa4\x90\x11\xe2\xcb\xf7\xeeH\xce7:\x11D\x12R\xae\xecB]\xbb3\xf3\x90\xfe\x93\xf6\x0e\xedY\x11\xbf\x85\x8cH+\x0c\xefN]\x00\nmB\xd1\x0f\xb9\xec\xa5\xddv\xaezO\x08\xf4C.\x1d\x9d\x8b\xfc\xb8\xbb)9<9\x86ue\x16M\\\xd0^	5\xba\x0b\xbd\x93s3\xb3\x1d!\xfftoI\xdf=O8y3\x06u=\x8a/\x1d\xbf\x84w\xe6.e\xb3:\x8e\xeb8\x9c\x85\x8bz%\x9f\x1c\xc2\x85\xdcL\xd6)\xd9;\xb6\x9f-\xcc9\xc2\xc3\xb56\xea	\xc7-\x0dMB\x93|l\xd3\xa4\x1c\xdc'`M\xd8\xd6j\xb7S\xd7\xdc-lk\xec\x83\x0b\xf3\xf5U\xf3\xfe+\xca\xdd= \x9cyV\x94\xe4\x83jg\xff>\xe1\x16\x8e7\xc0\xf7\x11\x93!\x12!\x9d\x13\xe6 \x87$\x9e\xec\xc6{\xa25\xfa]<\x0d\xcb\x84\x15\xe5\xc8[~+\xc4\xceU\xee\xa7\xc2Q'\xe09\x05\xd3\xd3\xbe\x8b\x92_Y\xf3\xb7\xc8]\xf7\xde\x94\x84\xe3\xdc\xbf.7\xe0\"\x11r\x82\x17.\x9f\xa3\x98=\xbb\xe7\xefA7\xef9\xa8\xf7\xff8	>k\xaf\xb7\xadG.\xa1!\xebO\xb1\xac\x9b	\x97\xd0\x1c\xf4q\xd7\xe1\x8f\xb8v\x96\xbc4x\xe0\x90q\\\xf0\xd2!\x16\x9cK\xff\xf5\xbc<\x1cU\x84\xbf\xb2\xd9{*\xc5\xdcS\xa9\xf4=E?\xd0\xe9\xdf\x8e\x82\xe7IA\xf3\xe3\xeeR\xe1\xbf\xe7OM\xf2\xfe9\xd2\x99\xda\x0ez9\xc3\xea5\x0c\xee\x9bt\xb0o\xf0\xea\xf7pc\x8a\xf25\xec\xa1\xe0\xc2\x11\x15\xc8\x8e\xa72\xb9\xe6\xd66\x9c\xf3\x07\x0d\xab\x9aN\xce\x94\xfa\xfa9\x0f\xb6\x87!\xa4\x7f\xb2\xc1B_b\xf3\x1a\xe4\xa1n\xc8vB\x14m\xdf#\x87\xf9\xcasjvT\xe6q\xe9({<|ZvF\xe1\x88\x02,\xb6\xdd\x80\x14\xbd\xb5\x9b\xddm|bY\xa0\\\xc9\xb1t\xfd\xbc\xf5;\xaa\xf8k*sO}\xff\xb7U\xf3=\xdf\xb0\xf5\x85\xe1\x92$b:\xd0\xd4m\xac\xf9\xeex<\x9e\xba\xab]h\xb9J\xafwr>H\xcb\x8fj\xe7\xa3\xbe\xe7\x19k\x0bs\x80?\xad\xe2\xcaL\xd7\x13\x92e\x18\xd8\xa3\x1eD\xcfL\xac\xdfp\x05\xed\xd9\x82\x8c]\x12i\xe4\xdf\xd6\xd5\xc7\xd7\x91h\xf7\x1f\x08n\x8f9l\xf2_\xd8d\xfa\xee/\xfd\xe97H\x87\xda\xbe\xa7\x99\xff_\x8d*\x99\x91\xd32<\\\xb0v\x19[$\xa1\xcd\xac\x1b\x1bU\x97\xb3\x80B\xf0J\n\xc9\xf1\x07\x82\xdaJ\xe8PD{9\xab\xe5b\"\xd9\x02\xe5 \x1d\x92\xb7\x82\x9b]W	l\xcah\xb1\xed\x95\x9fj\xc8\xde#\xa3p\x0dy\xf0\xa0;M\x04\xb7\xac\xd8C\xc6\xf9{\xe1\x8bW\x02\x87\x88\xe2\xe7\x10\xef\xe7\xce[\x01^G\xdb\xe1\xab\xb6\xad\x0b\xd8\x06vR\x07P	\xe6!\xb3\x0b\xf0QZs\xc5\x13\xbd\xbd\xfa{+S\x84\xd6\xf2\xffA~\xac\x9d\\\xffZ\xfb)\xf9\xfd\x83\xf3\xbb'\"\x8d\xe0w\xdc2\x9eS\x92\x0e]\xf79F\xb2\x99\xf5\xaf\xbe\x932\x9e\x1fO\x07iM\xf0\xe3r\x8az\x82D\xcfu\xb4\x99;\xa4\x0e\x99\x9e\xb3L\xac\x1f'\xc5\xa8`\xf3\xe7\xd8\xd6?\xa6\x88\x19\xad\x11Q\xd4\xef\x04\xe4\x8f@\x12O\xc95\xda\x97\x1d\x0dOI2\xffrw\xf7\x94\xd4<C\x7f\xf9\xbf\x01\x00PK\x07\x08\x10[\xf2'\x95\x11\x00\x00X?\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00uZXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x00doc_template.mdUT\x05\x00\x01\xcfi\x17b\xac\x93\xcf\x8e\xda0\x10\xc6\xef~\x8a\x91\x82D+A\xd4s\xa4\"Qz\x80\x03\xa8\xa5j\xef\x16\x99\x12\x97\xc4Nc\x075r\xfc\xee\x95\xff\x04\xf0&\xbb\xab\x95\xf6\x02\xe3\x19\xfb\xe7\xcf\xdfL\x12\xd0:=\xd0\n\x8d!de\x17_Q\x9e\x1aV+&\xb8\xcd\xfd\xc2F2\xc13[\n\xb1M\xaf[U\x88\xc6e}h\x93I\x02;\xce\x14\xfc8\x89\x1a	I\x92\x04\xb6Hsl$!=pZa\x0fWZ\xb6\x08=\xe4\xf7k\xa0'=,\x97K\x88~\x89\xd6\x0d\xe5g\x84\x19[\xc0\xec\n\xd9gH\x03\xcd\x98\x1e\xb4\x9e1c\xc0\x05W\x17\xf83\xc8\xf3 e\xfdmGb\x08SX9\xce\xbaf;\x85\x95\x04c\x9cJ\x0bSX\xa5G\xfc\xdb\xa2T\xe9\x1eU!rcF\x85\x9fM9\x91\x0d\xfei\xcd~C\\\x89\xcd\\\x8dN\xc6\xf5A\xfd\x04\xe8{\x8bM\xe7\xd5&\xe0\x16\x93\x9ey\x97\xa1\x07\xd5\xd5c\x97_\xe0\x7f\x11\xf9\x0do\xe3WHSw\xdf\xac\xbe`g;FK\xebu\xfc\x8c\xb8\x81\x17\xecn-\xa4\xe5\xa8\x89\x83\\\xaf\xea\x88\xb2\x16\\\"!\x01\x92\xbd\xcfP\x0d\x02=\xfd\x0d#\x16\xb9\x18N\x07\x1b\xed_F\xe6\xf39\xd1\xfa\x8f\x14\xfc\x99m\xc3\x03\xfd\xc6\xf0X\x87\xe5\x08\x1fJ\xe40|r\x07\xa1P\xa6\x9b\xc2\x1a,?\xc2'\xd7*8bI%\x82+>\x8e:\xcf\xf1\xdf\xe2>\xedS\x8c'\x83\xbf\x11U\xc5T\xba\xa5\xb2\xf0\x1a\xa2\xf4\x1e\xa5\xa4g|P\xac5\xf2\xdc\x98\xff\x03\x00PK\x07\x08\xdd\xeb\xa6\xb8\x86\x01\x00\x00>\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd0\x8aS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x00report_template.htmlUT\x05\x00\x01\xd8Q\xd6j\xa4X]o\xdb8\xd6\xbe\xcf\xaf8\xaf\n\xbcN\x01K\xb6\x934MU\xd9\x8bt\x9a`\x06\x98\x99\x0d6\x9d\x8b\xbd\xa4\xa5#\x8b\x08MjH\xdaIV\xa3\xff\xbe\xa0H\xea+J\xda\xdd\xcd\x8d\xa5\xf3\xc5\xe7\x9c\xf3\xf0\x90J\xf2\x7f_\xff\xfe\xd3\xb7\x7f\xde\xdd@\xa1\xf7ls\x92\x98\x1f`\x84\xef\xd6\x01\xf2`sr\x92\x14H\xb2\xcd	@\xb2GM -\x88T\xa8\xd7\xc1A\xe7\xe1U\xd0)8\xd9\xe3:8R|,\x85\xd4\x01\xa4\x82k\xe4z\x1d<\xd2L\x17\xeb\x0c\x8f4\xc5\xb0y\x99\x03\xe5TS\xc2B\x95\x12\x86\xeb\x95\x0d\xa3\xa9f\xb8\xa9\xaa\xe8w\xb2\xc7\xba\x86\x10J\x9a\x12\x90h\x02&\x0b\xab6\xeb)\xfdl\x9f\x00\xb6\"{\x86\xaay\x04\xd8\x13\xb9\xa3<\x86\xe5g'(I\x96Q\xbe\x8b\xe1\xec\xa2|\xf2\xc2T0!cxwvq\xf6\xe9\x0c\xbd4\x17\\\x879\xd9S\xf6\x1cCH\xca\x92a\xa8\x9e\x95\xc6\xfd\x1c\xbe0\xca\x1f~#\xe9}\xf3~+\xb8\x9eCp\x8f;\x81\xf0\xc7/\xc1\x1c~FvDMS2\x87kI	\x9b\x83\"\\\x85\n%\xcd\x07\xf1\x15\xfd\x17\xc6\xb0j\xc1\xd4'\x8d\xb6X\xbdL\x01\x96\xd0\xc3\xdc\xf3>\x1b{GM_\xaaQv\x97\xe4\xe3\xf9\xc7lh\xa8\x0e\xfb=\x91]\xbd2\xaaJF\x9ec\xc8\x19\xb6K\xedH\x19\xc3\xea\xac[\xdbWuuY>\xc1r\x00\xbc\x8d\x98\xd1#T\xe3\xa2_\x95O\x8d\x93\x0f\xb4\x152C\x19\xc3\xaa|\x02%\x18\xcd\xe0\x1d\xae\xf0\x02\xaf\x86\x06\xa1$\x19=\xa8\x18.GejWSZ\n\xbe{\x99\xc6\x96\x89\xf4a\xaad\xcbq\xa4\x9c2\x8dR\xc1\xf6\xa0\xb5\xe0/\xa1_\x94O\x83\x1a\xfc\xd7\xd0\x01\xb6$}\xd8Iq\xe0Y\x0c\xefr\x92o\xf3\xd4GM\x0fR\x19*\x96\x82r\x8d\xf2-\x88\x11I5=\"TSQ\x97\xe7\x97\x97\xd9\xe5\x10k\xe8i>Tzi\x9e\xe7\x83\x8ad\xa8	e*\xa2\x1a\xf7P\x8d:\x7f\xd55\xfe\xc7K\xc10\xd71\\tVgW\xe4\xe3\xc5\x87\x1f\xecu\x1fO\x94\x13\xca0\xebr\xb7\xaef\x816\xcb\xec\xe39\xb9\xf8\xf4z\x08\xf5@\xcb\xf2;1>}\xf8\x94\x91\x0f\xaf\xc2\x00O\xbfj<]\xaeFly\xb3\xaf&T8\x18[\xed\x94Z6\xfb\xae\x17\xca\xd3u\x8f\xba\x10\x1d\xf8f\x1a<\"\xdd\x15:\x86\xcb\xe5xOj\xa2\x0fjn_\xb2\x83$\x9a\n\xee^s\xca:\x0e\xe5L\x10\x1d\x834q|[\xecfw\xcd\xeb\x80\xbc=WJ\xa2\x14f\xa1\xc6'\x0d\xd5\xc8\xbe\xdfu\x9f\x8e\xed\xe7\xb4\xfdD#\x8bs\xa8&g\xd1+\x13\xf2\xc5|-e\x97\xf4\xebg\xc4U\x17L\x1cQ\xe6L<\xc6@\x0eZt\xb5y\n\x0bW\xf5\x8b\xabv\xa6\x8c\xb7\xf8e~\x95\x93\xcfC\x9e\xbd\x18i\x00\x8f\x05\xd5\x18\xaa\x92\xa4\x18\x1b\x84\xe1\xa3$e\xab\x142\x0b\xb7\x12\xc9C\x0c\xcdOH\x18\x1b\xb4Y\x93-\xc31\x9dS\xc1\x18)\x15\xc6\xe0\x9f\x86>\x1d\x85\xda\xac=\xe1\x9a\x87\xb6(G\x94\xe6<c!at\xc7c\xd0\xc2A\xab\xcd\xf9\xbbp\x07p\xb2\xb0w\x83\x93\xc4\xf0\xb99\x9a\x8bUw\x80'\x8bb\xd5\x08\xcd\xe9\x902\xa2\xd4:0gU`L\xee5\x91\x1a\xb3k]\xd7s\xc8)\xa7\xaa\xc0\x0c(\x87\xaa\x8a\xbe:\xd2\xd6u\xb2\xc8\xe8q\x1c\xc3\xed\xc3\xe6\xd2`5\x9b\xc4\x1e	\x9b\xaab\xc8!\xfaE\xe3^\x19o'&%Um\xa8\xa1\x8b\x0f\xda\xe3p\x83\xef\xaey\xef\xc5\xb0\x06\xdf\x89\xd2cv\x13\xe5\xb6y\xefE\xb1\x06\xafD\xd9\x8crwR\xbf\x87[\xaf\xf6\xa1\xaah\x0e\xd1\x8d\x94B\xd6ubh\xfe\n\x0eo\xb2(\xa5\xb9a!\xcf\xea\xa6\x93e\xeb`O\x1b_Sw.:\xa5={\x02\xc8\x88&\xa1\xb5\\\x07\x84\xb1`s\xcdX\xb2\xb0\xc6C\xcf\x81\xa9M:\xd8\xdc\xba\xe4\xbf\xeb`k\x1dl\xee\\\xcd;\x87dQ\x9a\x9f\xaa\x92\x84\xef\xb0\xed\xb4\xc9\xc5\x1d\x19\x1e\xb4\x99\xb3\x86L\xf7\x9ah\xac\xeb\xc0\x16\x8b#X	\xf8E\xeaZ\x94\xc8]M\\\x12\x8ea\x1b\xb7\x1b\x12U\x92\xb6\x18v\x167U\xfd\xadyl\xba[\x12\xbe1\xab\xfd!Y]CGGw\x97m\xfb\xef\"6X\xa2[\xca\xb0\xae\x07\xd1\xcd\x80nb;\x9d\x8d\xdcu\xcc\xfc\x0d\x1c<9\x82\x17\xe41\x90\x06\xcb\x99\xbc\x0fj\xb4\xa0j\x84~KZ\xfd\xcbE\x93\xc5\xa0$\xfd\x1d\xdd\x9eg\xc1p5G9'K\x8a\xf3In6V\xc9\xa28\xf7\xce\x86\xc5/\x18\xebt\xc32\xd8\x1a^+ef\x95\xe0\xaaU$\xc5\xf9\xa6\x13\x0f\x827S\xd3\x87\xeb\xd1h\"\x8a\xf9 \x91\x9d\xa9y\xcd|\nvi?\"z\xa3\xa3\xaa\x90)\xac\xeb^\x8e\x0et\xb0\x19:\xfd\xff\xbb\xd5r\xb9<\xff\xec=\xec\xfb\xc7\xcf\xce<Y\xe8l\xb4x\x7f\xb0N*\xef\x84\xa2\xbe\xfbS\x06\x86\xfeBw\x10\xf0\xa9\xc4T\x9bKQ\x15\xdd\xb8g3\x8dI\xaa\x0f\x84\x196_7Ou=	*Y\xf4\xeb\xe3L\x1c\xe4d1\xa8\xf4P\xd9 \x89\xfe\x81\x7f\x1eP\xe9\xce\xa58\xdf8Yc\xb0\xd3\x10]k\x8d\xfbR+X\x19\\U\xd5\n\xea\x1a\x88{t\xb1\xa78\xe4\xc2\xb5\xfb\x14z\xb2f\xa3\x9e\xb4\x04\xf0\xa6?#\xc9P*\x93r\xd4\xe8]\xf0\x01\x0b{\xf0\xa3/\"{\xb6\xb3wSUc\xe9h\xdc\xbe\xac\x93\x8f\xa5J\xc1\x15\xb6b[\x0b+\x9cN\xcc\xea\xa2;)\xb4\xa8k\xe8\xcb\xfc6\xee'\xe7T\xffAv\xcec\x9c\xdeP\xfc\x83\xf9}\xa3{\xcaw\x83N[\xd1 \xb7\xd1\xdeL\xb4\xdc\x18\xd6g\\5tv$\xb7\x8e\xd1\xd7\xdf\xef\x1d\x1f\x874\xf4^\xa9\xe0\x1cS=\xe1\xf9\x93\xd5\xbc\xe9\xad\xd9\xd4\x9a\xdf~}{\xcd\x9cJ\xa5a\xfb\xacq\xc2\xf9\xd6(\xbf<k|{aI\xb8\xcaQN\xad\xeeToB\xd0B\x136\xe5l\xe4\x13\x9eo\xec\xd3\xfem\xc3\x9e\xac&\xe3\xae\xd9\x89J%-\xb5\x11\x02d\"=\xec\x91\xeb\xe8\xcf\x03\xca\xe7{d\x98j!\xaf\x19;\x9d\x8d\xbe\xb5g\xef\xa3\\\xc8\x1b\x92\x16\xa7\xf9\x81\xa7f^\xc1\xa9U\xbdoo\xa7\xfe\x9b7\xcbn\x8e\xc8\xf5\xafTi\xe4(Og)\xa3\xe9\xc3l\x0e\x9dk\xe7\x04p$\x12\xecj\xb0\xf61v\xa8\xaf\xb5\x96t{\xd0x:\xeb\xdd2f\xef\xfde\xf7\x7f\x87\xdf\x07\x01\xb0\x8d\x9ac\xc2\xa0\x8e\xb4\xd8\xed\x18\x9e\xce\xec\x15j6\x87-\xac\xd7\x1e\\\x0fA\xfdch\\'\x9a\x8f\xc8I(F1Dc$Qsa\x8f\xdc\xff{`\xddVi\xbd\x86\x19al\x06\x7f\xfde\x0d;\xe4\xa9\xe0\x9aP\xaeN\xad\xed{\xf8\x1b\xccf\x10\xc3\x8c\x0b\x8e\xb3)\xe4>\x07\xfb\x9b,<A\x92\x85\xfd<8I\x16\x85\xde\xb3\xcd\xc9\xbf\x07\x00PK\x07\x08;R-I\xbe\x06\x00\x00u\x14\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00uZXT\x9e\xa8<E\x19\x01\x00\x00\xaa\x01\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x00api_file_template.funUT\x05\x00\x01\xcfi\x17bPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00uZXT0^\x87\xbdK\x00\x00\x00\xc2\x00\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81e\x01\x00\x00copyright.txtUT\x05\x00\x01\xcfi\x17bPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00uZXT\x10[\xf2'\x95\x11\x00\x00X?\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xf4\x01\x00\x00doc_template.htmlUT\x05\x00\x01\xcfi\x17bPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00uZXT\xdd\xeb\xa6\xb8\x86\x01\x00\x00>\x04\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xd1\x13\x00\x00doc_template.mdUT\x05\x00\x01\xcfi\x17bPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd0\x8aS];R-I\xbe\x06\x00\x00u\x14\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9d\x15\x00\x00report_template.htmlUT\x05\x00\x01\xd8Q\xd6jPK\x05\x06\x00\x00\x00\x00\x05\x00\x05\x00i\x01\x00\x00\xa6\x1c\x00\x00\x00\x00"
 	fs.Register(data)
 }
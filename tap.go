@@ -0,0 +1,71 @@
+package pica
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TAPReporter writes the Test Anything Protocol version 13, a test point for every item
+// with the error as yaml diagnostics, and the plan when the outermost run ends
+type TAPReporter struct {
+	writer io.Writer
+	runs   runDepth
+	count  int
+}
+
+func NewTAPReporter(w io.Writer) *TAPReporter {
+	return &TAPReporter{writer: w}
+}
+
+func (t *TAPReporter) RunStart(name string, items []*ApiItem) {
+	if t.runs.start() {
+		t.count = 0
+		fmt.Fprintln(t.writer, "TAP version 13")
+	}
+	if name != "" {
+		fmt.Fprintf(t.writer, "# %s\n", name)
+	}
+}
+
+func (t *TAPReporter) ItemStart(item *ApiItem) {}
+
+func (t *TAPReporter) RequestSent(req *ApiRequest, httpReq *http.Request, attempt int) {}
+
+func (t *TAPReporter) ResponseReceived(req *ApiRequest, res *http.Response, body []byte, err error) {
+}
+
+func (t *TAPReporter) AssertionResult(item *ApiItem, assertion *Assertion) {}
+
+func (t *TAPReporter) ItemEnd(item *ApiItem, result *ApiResult) {
+	t.count++
+	description := strings.TrimSpace(fmt.Sprintf("%s %s %s", result.Name, result.Method, result.Url))
+	switch {
+	case result.Skipped:
+		fmt.Fprintf(t.writer, "ok %d - %s # SKIP %s\n", t.count, description, result.Error)
+	case result.Passed:
+		fmt.Fprintf(t.writer, "ok %d - %s\n", t.count, description)
+	default:
+		fmt.Fprintf(t.writer, "not ok %d - %s\n", t.count, description)
+		fmt.Fprintln(t.writer, "  ---")
+		if result.Status > 0 {
+			fmt.Fprintf(t.writer, "  status: %d\n", result.Status)
+		}
+		fmt.Fprintln(t.writer, "  message: |")
+		for _, line := range strings.Split(result.Error, "\n") {
+			fmt.Fprintf(t.writer, "    %s\n", line)
+		}
+		fmt.Fprintln(t.writer, "  ...")
+	}
+}
+
+func (t *TAPReporter) RunEnd(results []*ApiResult, err error) {
+	if !t.runs.end() {
+		return
+	}
+	if err != nil && t.count == 0 {
+		fmt.Fprintf(t.writer, "Bail out! %s\n", strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+	fmt.Fprintf(t.writer, "1..%d\n", t.count)
+}
@@ -0,0 +1,123 @@
+package pica
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/jerloo/funny"
+)
+
+// Timing the phases of one http request. Phases skipped, like dns for ips or connect
+// for reused connections, are zero, and the phases of redirects are added up.
+type Timing struct {
+	// Start when the request was started
+	Start   time.Time
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// FirstByte from the start of the request to the first byte of the response
+	FirstByte time.Duration
+	// Transfer from the first byte to the end of the body
+	Transfer time.Duration
+	Total    time.Duration
+}
+
+// Map the timing in milliseconds, bound as `timing` in the response block
+func (t *Timing) Map() map[string]funny.Value {
+	return map[string]funny.Value{
+		"dns":       int(t.DNS / time.Millisecond),
+		"connect":   int(t.Connect / time.Millisecond),
+		"tls":       int(t.TLS / time.Millisecond),
+		"firstByte": int(t.FirstByte / time.Millisecond),
+		"transfer":  int(t.Transfer / time.Millisecond),
+		"total":     int(t.Total / time.Millisecond),
+	}
+}
+
+type timingKey struct{}
+
+// timingTrace records the phases of a request by httptrace
+type timingTrace struct {
+	mu        sync.Mutex
+	start     time.Time
+	firstByte time.Time
+	dnsStart  time.Time
+	connStart time.Time
+	tlsStart  time.Time
+	end       time.Time
+	timing    Timing
+}
+
+// withTiming trace req, the timing is read from its response by ResponseTiming
+func withTiming(req *http.Request) *http.Request {
+	t := &timingTrace{start: time.Now()}
+	ctx := context.WithValue(req.Context(), timingKey{}, t)
+	return req.WithContext(httptrace.WithClientTrace(ctx, t.trace()))
+}
+
+func (t *timingTrace) trace() *httptrace.ClientTrace {
+	// since records the phase started at *from, dials of both ip versions may race
+	since := func(from *time.Time, phase *time.Duration) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !from.IsZero() {
+			*phase += time.Since(*from)
+			*from = time.Time{}
+		}
+	}
+	begin := func(at *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if at.IsZero() {
+			*at = time.Now()
+		}
+	}
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { begin(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { since(&t.dnsStart, &t.timing.DNS) },
+		ConnectStart: func(network, addr string) {
+			begin(&t.connStart)
+		},
+		ConnectDone: func(network, addr string, err error) {
+			since(&t.connStart, &t.timing.Connect)
+		},
+		TLSHandshakeStart: func() { begin(&t.tlsStart) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			since(&t.tlsStart, &t.timing.TLS)
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.firstByte = time.Now()
+		},
+	}
+}
+
+// ResponseTiming the timing of res, ending when it is first called after the body is read.
+// It is nil for responses not sent by the runner.
+func ResponseTiming(res *http.Response) *Timing {
+	if res == nil || res.Request == nil {
+		return nil
+	}
+	t, ok := res.Request.Context().Value(timingKey{}).(*timingTrace)
+	if !ok {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.end.IsZero() {
+		t.end = time.Now()
+	}
+	timing := t.timing
+	timing.Start = t.start
+	timing.Total = t.end.Sub(t.start)
+	if !t.firstByte.IsZero() {
+		timing.FirstByte = t.firstByte.Sub(t.start)
+		timing.Transfer = t.end.Sub(t.firstByte)
+	}
+	return &timing
+}
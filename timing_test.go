@@ -0,0 +1,48 @@
+package pica
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRunner_Timing(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("last"))
+	}))
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+tls = {
+    insecureSkipVerify = true
+}
+
+// GET /slow slow
+// Response
+assert(timing.firstByte >= 30)
+assert(timing.total >= timing.firstByte + timing.transfer)
+expectEqual(timing.dns, 0)
+`))
+	runner.Reporter = NewOutput(true, buf)
+	assert.Nil(t, runner.Run())
+
+	timing := runner.Results[0].Timing
+	assert.NotNil(t, timing)
+	assert.True(t, timing.Connect > 0)
+	assert.True(t, timing.TLS > 0)
+	assert.True(t, timing.FirstByte >= 30*time.Millisecond)
+	assert.True(t, timing.Transfer >= 20*time.Millisecond)
+	assert.True(t, timing.Total >= timing.FirstByte+timing.Transfer)
+	assert.Contains(t, buf.String(), "first byte ")
+}
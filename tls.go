@@ -0,0 +1,124 @@
+package pica
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jerloo/funny"
+)
+
+// TLSSettings the `tls` block of a file, an environment or an api
+//
+//	tls = {
+//	    ca = 'certs/ca.pem'
+//	    cert = 'certs/client.pem'
+//	    key = 'certs/client-key.pem'
+//	    serverName = 'api.internal'
+//	    minVersion = '1.2'
+//	    insecureSkipVerify = false
+//	}
+//
+// Paths are relative to the pica file.
+type TLSSettings struct {
+	CA                 string
+	Cert               string
+	Key                string
+	ServerName         string
+	MinVersion         string
+	InsecureSkipVerify bool
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSSettings parse the tls block, nil when absent
+func newTLSSettings(val funny.Value) (*TLSSettings, error) {
+	if val == nil {
+		return nil, nil
+	}
+	block, ok := normalize(val).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unsupport type [%s], only support [map]", funny.Typing(val))
+	}
+	if len(block) == 0 {
+		return nil, nil
+	}
+	settings := &TLSSettings{}
+	for key, val := range block {
+		switch key {
+		case "ca":
+			settings.CA = fmt.Sprint(val)
+		case "cert":
+			settings.Cert = fmt.Sprint(val)
+		case "key":
+			settings.Key = fmt.Sprint(val)
+		case "serverName":
+			settings.ServerName = fmt.Sprint(val)
+		case "minVersion":
+			settings.MinVersion = strings.TrimPrefix(strings.ToLower(fmt.Sprint(val)), "tls")
+			if _, ok := tlsVersions[settings.MinVersion]; !ok {
+				return nil, fmt.Errorf("unsupport minVersion [%v], only support [1.0][1.1][1.2][1.3]", val)
+			}
+		case "insecureSkipVerify":
+			insecure, ok := val.(bool)
+			if !ok {
+				return nil, fmt.Errorf("insecureSkipVerify must be a bool")
+			}
+			settings.InsecureSkipVerify = insecure
+		default:
+			return nil, fmt.Errorf("unknown setting [%s]", key)
+		}
+	}
+	if (settings.Cert == "") != (settings.Key == "") {
+		return nil, fmt.Errorf("client certificates require both cert and key")
+	}
+	return settings, nil
+}
+
+// config the tls config of the settings, reading the files relative to the runner file
+func (settings *TLSSettings) config(runner *APIRunner) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         settings.ServerName,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+		MinVersion:         tlsVersions[settings.MinVersion],
+	}
+	if settings.CA != "" {
+		data, err := ioutil.ReadFile(runner.resolvePath(settings.CA))
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in ca %s", settings.CA)
+		}
+		config.RootCAs = pool
+	}
+	if settings.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(runner.resolvePath(settings.Cert), runner.resolvePath(settings.Key))
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// tlsVersionName like TLS 1.3
+func tlsVersionName(version uint16) string {
+	for name, v := range tlsVersions {
+		if v == version {
+			return "TLS " + name
+		}
+	}
+	return fmt.Sprintf("TLS 0x%04x", version)
+}
@@ -0,0 +1,120 @@
+package pica
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCert create a certificate signed by parent, or self signed when parent is nil
+func newTestCert(t *testing.T, name string, parent *tls.Certificate) (*tls.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"api.internal"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, interface{}(key)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer = parent.Leaf
+		signerKey = parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	assert.Nil(t, err)
+	cert.Leaf, err = x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return &cert, certPem, keyPem
+}
+
+func TestAPIRunner_TLS(t *testing.T) {
+	ca, caPem, _ := newTestCert(t, "pica ca", nil)
+	serverCert, _, _ := newTestCert(t, "api.internal", ca)
+	_, clientPem, clientKeyPem := newTestCert(t, "pica client", ca)
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caPem)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{*serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "pica-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "ca.pem"), caPem, 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "client.pem"), clientPem, 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "client-key.pem"), clientKeyPem, 0644))
+	filename := filepath.Join(dir, "pica.fun")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+tls = {
+    ca = 'ca.pem'
+    cert = 'client.pem'
+    key = 'client-key.pem'
+    serverName = 'api.internal'
+    minVersion = '1.2'
+}
+
+// GET /whoami whoami
+// Response
+expectEqual(text, 'pica client')
+`), 0644))
+
+	buf := new(bytes.Buffer)
+	runner := NewAPIRunnerFromFile(filename, nil, 0)
+	runner.Reporter = NewOutput(true, buf)
+	assert.Nil(t, runner.Run())
+	assert.Contains(t, buf.String(), "TLS 1.3")
+	assert.Contains(t, buf.String(), "api.internal issued by pica ca")
+
+	// without the client certificate the handshake fails
+	assert.Nil(t, ioutil.WriteFile(filename, []byte(`
+baseUrl = '`+server.URL+`'
+tls = {
+    insecureSkipVerify = true
+}
+
+// GET /whoami whoami
+`), 0644))
+	runner = NewAPIRunnerFromFile(filename, nil, 0)
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.NotNil(t, runner.Run())
+}
@@ -0,0 +1,322 @@
+package pica
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jerloo/funny"
+	"golang.org/x/net/http2"
+)
+
+// DefaultMaxRedirects the max count of redirects followed when followRedirects is on
+var DefaultMaxRedirects = 10
+
+// Connection how the client of an api connects, resolved from the vm variables `tls`,
+// `proxy`, `noProxy`, `followRedirects`, `httpVersion` and `keepAlive`, which are
+// overridden by the annotations of the api like `// followRedirects: off`
+type Connection struct {
+	TLS *TLSSettings
+	// Proxy http, https, socks5 or socks5h url, empty for the proxy of the environment, off for none
+	Proxy string
+	// NoProxy hosts, domains and cidrs reached without Proxy, separated by commas, defaults to NO_PROXY
+	NoProxy string
+	// HTTPVersion 1.1 or 2 forces the protocol, empty negotiates it
+	HTTPVersion string
+	// KeepAlive reuses the connections between requests
+	KeepAlive bool
+	// MaxRedirects the max count of redirects followed, 0 follows none
+	MaxRedirects int `json:"-"`
+}
+
+// Connection resolve the connection settings of req
+func (runner *APIRunner) Connection(req *ApiRequest) (*Connection, error) {
+	settings, err := newTLSSettings(runner.vm.LookupDefault("tls", nil))
+	if err != nil {
+		return nil, fmt.Errorf("tls %s", err.Error())
+	}
+	connection := &Connection{
+		TLS:          settings,
+		KeepAlive:    true,
+		MaxRedirects: DefaultMaxRedirects,
+	}
+	for _, name := range []string{"proxy", "noProxy", "followRedirects", "httpVersion", "keepAlive"} {
+		var val funny.Value
+		if annotation, ok := req.Annotations[name]; ok {
+			val = annotation
+		} else {
+			val = runner.vm.LookupDefault(name, nil)
+		}
+		if val == nil {
+			continue
+		}
+		err := connection.set(name, val)
+		if err != nil {
+			return nil, fmt.Errorf("%s of %s %s: %s", name, req.Method, req.Url, err.Error())
+		}
+	}
+	if connection.Proxy != "" && connection.Proxy != "off" && connection.NoProxy == "" {
+		connection.NoProxy = os.Getenv("NO_PROXY")
+		if connection.NoProxy == "" {
+			connection.NoProxy = os.Getenv("no_proxy")
+		}
+	}
+	return connection, nil
+}
+
+func (connection *Connection) set(name string, val funny.Value) (err error) {
+	switch name {
+	case "proxy":
+		connection.Proxy = strings.TrimSpace(fmt.Sprint(val))
+		if on, err := boolValue(val); err == nil && !on {
+			connection.Proxy = "off"
+		}
+		if connection.Proxy == "off" {
+			return nil
+		}
+		u, err := url.Parse(connection.Proxy)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("unsupport scheme [%s], only support [http][https][socks5][socks5h]", u.Scheme)
+		}
+	case "noProxy":
+		connection.NoProxy = strings.Join(stringList(val), ",")
+	case "followRedirects":
+		if on, err := boolValue(val); err == nil {
+			connection.MaxRedirects = 0
+			if on {
+				connection.MaxRedirects = DefaultMaxRedirects
+			}
+			return nil
+		}
+		connection.MaxRedirects, err = intValue(val)
+	case "httpVersion":
+		connection.HTTPVersion = strings.TrimPrefix(strings.ToUpper(fmt.Sprint(val)), "HTTP/")
+		switch connection.HTTPVersion {
+		case "1.1", "2", "":
+		case "1":
+			connection.HTTPVersion = "1.1"
+		default:
+			return fmt.Errorf("unsupport version [%v], only support [1.1][2]", val)
+		}
+	case "keepAlive":
+		connection.KeepAlive, err = boolValue(val)
+	}
+	return
+}
+
+// standard whether the default transport connects as the settings want
+func (connection *Connection) standard() bool {
+	return connection.TLS == nil && connection.Proxy == "" && connection.HTTPVersion == "" && connection.KeepAlive
+}
+
+// checkRedirect follow at most MaxRedirects redirects, returning the last response when it is 0
+func (connection *Connection) checkRedirect(req *http.Request, via []*http.Request) error {
+	if connection.MaxRedirects == 0 {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > connection.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", connection.MaxRedirects)
+	}
+	return nil
+}
+
+// proxyFunc the proxy of a request url, nil for the direct connections
+func (connection *Connection) proxyFunc() func(*http.Request) (*url.URL, error) {
+	switch connection.Proxy {
+	case "":
+		return http.ProxyFromEnvironment
+	case "off":
+		return nil
+	}
+	proxy, _ := url.Parse(connection.Proxy)
+	return func(req *http.Request) (*url.URL, error) {
+		if noProxy(connection.NoProxy, req.URL) {
+			return nil, nil
+		}
+		return proxy, nil
+	}
+}
+
+// noProxy whether u matches one of the hosts, domains (with or without a leading dot),
+// ips or cidrs of the list, `*` matches every url
+func noProxy(list string, u *url.URL) bool {
+	host := u.Hostname()
+	ip := net.ParseIP(host)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		if item == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(item); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, port, err := net.SplitHostPort(item); err == nil {
+			if port != u.Port() {
+				continue
+			}
+			item = h
+		}
+		item = strings.TrimPrefix(item, ".")
+		if host == item || strings.HasSuffix(host, "."+item) {
+			return true
+		}
+	}
+	return false
+}
+
+// transport create the transport of the settings, reading the tls files relative to the runner file
+func (connection *Connection) transport(runner *APIRunner) (http.RoundTripper, error) {
+	var config *tls.Config
+	if connection.TLS != nil {
+		var err error
+		config, err = connection.TLS.config(runner)
+		if err != nil {
+			return nil, fmt.Errorf("tls %s", err.Error())
+		}
+	}
+	if connection.HTTPVersion == "2" {
+		if connection.Proxy != "" && connection.Proxy != "off" {
+			return nil, fmt.Errorf("proxy is not supported with httpVersion 2")
+		}
+		return &http2Transport{
+			tls: &http2.Transport{TLSClientConfig: config},
+			// h2c by prior knowledge
+			plain: &http2.Transport{
+				AllowHTTP: true,
+				DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+					return net.Dial(network, addr)
+				},
+			},
+			close: !connection.KeepAlive,
+		}, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	transport.Proxy = connection.proxyFunc()
+	transport.DisableKeepAlives = !connection.KeepAlive
+	if connection.HTTPVersion == "1.1" {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport, nil
+}
+
+// http2Transport sends every request by HTTP/2, negotiated by tls or by prior knowledge for http urls
+type http2Transport struct {
+	tls   *http2.Transport
+	plain *http2.Transport
+	close bool
+}
+
+func (t *http2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.close {
+		closing := *req
+		closing.Close = true
+		req = &closing
+	}
+	if req.URL.Scheme == "http" {
+		return t.plain.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
+
+// transportCache the transports of a run by their settings, so that apis
+// with the same settings reuse the connections
+type transportCache struct {
+	mu         sync.Mutex
+	transports map[string]http.RoundTripper
+}
+
+func newTransportCache() *transportCache {
+	return &transportCache{transports: map[string]http.RoundTripper{}}
+}
+
+// get the transport of the settings key, created by create when missing
+func (c *transportCache) get(key string, create func() (http.RoundTripper, error)) (http.RoundTripper, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if transport, ok := c.transports[key]; ok {
+		return transport, nil
+	}
+	transport, err := create()
+	if err != nil {
+		return nil, err
+	}
+	c.transports[key] = transport
+	return transport, nil
+}
+
+// httpClient the client of req, configured by its Connection
+func (runner *APIRunner) httpClient(req *ApiRequest) (*http.Client, error) {
+	connection, err := runner.Connection(req)
+	if err != nil {
+		return nil, err
+	}
+	client := *runner.client
+	client.CheckRedirect = connection.checkRedirect
+	if connection.standard() {
+		return &client, nil
+	}
+	// paths of the tls settings are relative to the file
+	key, err := json.Marshal(connection)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := runner.transports.get(runner.resolvePath(".")+string(key), func() (http.RoundTripper, error) {
+		return connection.transport(runner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = transport
+	return &client, nil
+}
+
+// redirectChain the redirects followed before res, as maps of `status`, `url` and `location`
+func redirectChain(res *http.Response) []interface{} {
+	chain := []interface{}{}
+	for req := res.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		hop := map[string]funny.Value{
+			"status":   req.Response.StatusCode,
+			"location": req.URL.String(),
+		}
+		if req.Response.Request != nil {
+			hop["url"] = req.Response.Request.URL.String()
+		}
+		chain = append([]interface{}{hop}, chain...)
+	}
+	return chain
+}
+
+// boolValue bools, or strings like on, off, true, false, yes and no
+func boolValue(val funny.Value) (bool, error) {
+	switch val := val.(type) {
+	case bool:
+		return val, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(val)) {
+		case "on", "true", "yes":
+			return true, nil
+		case "off", "false", "no":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("unsupport value [%v], only support [on][off][true][false]", val)
+}
@@ -0,0 +1,217 @@
+package pica
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestAPIRunner_Connection(t *testing.T) {
+	runner := NewAPIRunnerFromContent([]byte(`
+proxy = 'socks5://127.0.0.1:1080'
+noProxy = ['localhost', '.internal']
+followRedirects = false
+
+// GET /api/users listUsers
+// followRedirects: 3
+// httpVersion: HTTP/1.1
+// keepAlive: off
+
+// GET /api/orders listOrders
+// proxy: off
+`))
+	runner.Parse()
+	runner.ParseAPIItems()
+	runner.RunInitLines()
+
+	connection, err := runner.Connection(runner.APIItems[0].Request)
+	assert.Nil(t, err)
+	assert.Equal(t, &Connection{
+		Proxy:        "socks5://127.0.0.1:1080",
+		NoProxy:      "localhost,.internal",
+		HTTPVersion:  "1.1",
+		MaxRedirects: 3,
+	}, connection)
+
+	connection, err = runner.Connection(runner.APIItems[1].Request)
+	assert.Nil(t, err)
+	assert.Equal(t, "off", connection.Proxy)
+	assert.Equal(t, 0, connection.MaxRedirects)
+	assert.True(t, connection.KeepAlive)
+
+	runner.vm.Assign("proxy", "ftp://127.0.0.1")
+	_, err = runner.Connection(runner.APIItems[0].Request)
+	assert.NotNil(t, err)
+}
+
+func TestNoProxy(t *testing.T) {
+	for _, item := range []struct {
+		list  string
+		url   string
+		match bool
+	}{
+		{"localhost", "http://localhost:8080/", true},
+		{".internal", "https://api.internal/", true},
+		{"internal", "https://internal/", true},
+		{"example.com", "https://notexample.com/", false},
+		{"10.0.0.0/8", "http://10.1.2.3/", true},
+		{"api.internal:8443", "https://api.internal/", false},
+		{"api.internal:8443", "https://api.internal:8443/", true},
+		{"*", "https://example.com/", true},
+		{"", "https://example.com/", false},
+	} {
+		u, _ := url.Parse(item.url)
+		assert.Equal(t, item.match, noProxy(item.list, u), item.list+" "+item.url)
+	}
+}
+
+func TestAPIRunner_Redirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/c", http.StatusMovedPermanently)
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(r.URL.Path))
+		}
+	}))
+	defer server.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+
+// GET /a follow
+// Response
+expectEqual(text, '/c')
+expectEqual(jsonpath('[0].status', redirects), 302)
+expectEqual(jsonpath('[1].location', redirects), baseUrl + '/c')
+
+// GET /a stay
+// followRedirects: off
+// Response
+expectEqual(status, 302)
+expectEqual(redirects, [])
+
+// GET /a once
+// followRedirects: 1
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	err := runner.Run()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "stopped after 1 redirects")
+	assert.Equal(t, 3, len(runner.Results))
+	assert.True(t, runner.Results[0].Passed)
+	assert.True(t, runner.Results[1].Passed)
+}
+
+func TestAPIRunner_Proxy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("direct"))
+	}))
+	defer target.Close()
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = 'http://api.internal'
+proxy = '` + proxy.URL + `'
+
+// GET /users proxied
+// Response
+expectEqual(text, 'proxied http://api.internal/users')
+
+// GET /users direct
+// proxy: off
+baseUrl = '` + target.URL + `'
+// Response
+expectEqual(text, 'direct')
+
+// GET /users excluded
+noProxy = '127.0.0.1'
+// Response
+expectEqual(text, 'direct')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+}
+
+func TestAPIRunner_HTTPVersion(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(r.Proto))
+	})
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	plain := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	defer plain.Close()
+
+	runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+tls = {
+    insecureSkipVerify = true
+}
+
+// GET /proto negotiated
+// Response
+expectEqual(text, 'HTTP/2.0')
+
+// GET /proto http1
+// httpVersion: 1.1
+// Response
+expectEqual(text, 'HTTP/1.1')
+
+// GET /proto h2c
+// httpVersion: 2
+baseUrl = '` + plain.URL + `'
+// Response
+expectEqual(text, 'HTTP/2.0')
+`))
+	runner.Reporter = NewOutput(false, new(bytes.Buffer))
+	assert.Nil(t, runner.Run())
+}
+
+func TestAPIRunner_KeepAlive(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	run := func(keepAlive string) int32 {
+		atomic.StoreInt32(&connections, 0)
+		runner := NewAPIRunnerFromContent([]byte(`
+baseUrl = '` + server.URL + `'
+keepAlive = '` + keepAlive + `'
+// GET /a a
+// GET /b b
+// GET /c c
+`))
+		runner.Reporter = NewOutput(false, new(bytes.Buffer))
+		assert.Nil(t, runner.Run())
+		return atomic.LoadInt32(&connections)
+	}
+	assert.Equal(t, int32(1), run("on"))
+	assert.Equal(t, int32(3), run("off"))
+}
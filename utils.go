@@ -34,6 +34,48 @@ func VmMap2HttpHeaders(vmMap map[string]funny.Value) http.Header {
 	return headers
 }
 
+func copyMap(m map[string]funny.Value) map[string]funny.Value {
+	result := make(map[string]funny.Value, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// copyScope deep copy the maps and lists of a scope, so that vms never share them
+func copyScope(scope funny.Scope) funny.Scope {
+	result := make(funny.Scope, len(scope))
+	for k, v := range scope {
+		result[k] = copyValue(v)
+	}
+	return result
+}
+
+func copyValue(val funny.Value) funny.Value {
+	switch val := val.(type) {
+	case map[string]funny.Value:
+		result := make(map[string]funny.Value, len(val))
+		for k, v := range val {
+			result[k] = copyValue(v)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			result[k] = copyValue(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(val))
+		for index, v := range val {
+			result[index] = copyValue(v)
+		}
+		return result
+	default:
+		return val
+	}
+}
+
 func CompileURL(url string, vm *funny.Funny) (string, Query, error) {
 	queryValue := vm.LookupDefault("query", nil)
 	query := Query{}
@@ -48,10 +90,14 @@ func CompileURL(url string, vm *funny.Funny) (string, Query, error) {
 	if err != nil {
 		return "", nil, err
 	}
+	var missing []string
 	result := reg.ReplaceAllStringFunc(url, func(repl string) string {
 		repl = repl[1 : len(repl)-1]
 		val := vm.Lookup(repl)
 		switch val := val.(type) {
+		case nil:
+			missing = append(missing, repl)
+			return ""
 		case int:
 			return fmt.Sprint(val)
 		case string:
@@ -62,6 +108,9 @@ func CompileURL(url string, vm *funny.Funny) (string, Query, error) {
 			panic(fmt.Errorf("unsupport type [%s], only support [int][string]", funny.Typing(val)))
 		}
 	})
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("variables [%s] of url %s were never produced, declare the apis assigning them with `// depends: apiName`", strings.Join(missing, ", "), url)
+	}
 	if query == nil || len(query) == 0 {
 		return result, nil, nil
 	}